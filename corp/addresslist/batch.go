@@ -0,0 +1,122 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package addresslist
+
+import (
+	"errors"
+	"net/url"
+
+	"github.com/chanxuehong/wechat/corp"
+)
+
+// 异步任务完成后的回调信息
+type BatchCallback struct {
+	URL            string `json:"url,omitempty"`            // 企业应用接收企业号推送请求的访问协议和地址, 支持http或https协议
+	Token          string `json:"token,omitempty"`          // 用于生成签名
+	EncodingAESKey string `json:"encodingaeskey,omitempty"` // 用于消息体的加密, 是AES密钥的Base64编码
+}
+
+// 增量更新成员.
+//  mediaId: 上传的csv文件的media_id
+//  callback: 非必须; 回调信息, 如填写该项则任务完成后, 通过callback推送事件给企业
+func (clt *Client) BatchSyncUser(mediaId string, callback *BatchCallback) (jobId string, err error) {
+	return clt.batchJob("https://qyapi.weixin.qq.com/cgi-bin/batch/syncuser?access_token=", mediaId, callback)
+}
+
+// 全量覆盖成员.
+//  mediaId: 上传的csv文件的media_id
+//  callback: 非必须; 回调信息, 如填写该项则任务完成后, 通过callback推送事件给企业
+func (clt *Client) BatchReplaceUser(mediaId string, callback *BatchCallback) (jobId string, err error) {
+	return clt.batchJob("https://qyapi.weixin.qq.com/cgi-bin/batch/replaceuser?access_token=", mediaId, callback)
+}
+
+// 全量覆盖部门.
+//  mediaId: 上传的csv文件的media_id
+//  callback: 非必须; 回调信息, 如填写该项则任务完成后, 通过callback推送事件给企业
+func (clt *Client) BatchReplaceParty(mediaId string, callback *BatchCallback) (jobId string, err error) {
+	return clt.batchJob("https://qyapi.weixin.qq.com/cgi-bin/batch/replaceparty?access_token=", mediaId, callback)
+}
+
+func (clt *Client) batchJob(incompleteURL, mediaId string, callback *BatchCallback) (jobId string, err error) {
+	if mediaId == "" {
+		err = errors.New("empty mediaId")
+		return
+	}
+
+	var request = struct {
+		MediaId  string         `json:"media_id"`
+		Callback *BatchCallback `json:"callback,omitempty"`
+	}{
+		MediaId:  mediaId,
+		Callback: callback,
+	}
+
+	var result struct {
+		corp.Error
+		JobId string `json:"jobid"`
+	}
+
+	if err = ((*corp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	jobId = result.JobId
+	return
+}
+
+const (
+	BatchJobStatusPending    = 1 // 任务开始
+	BatchJobStatusProcessing = 2 // 处理中
+	BatchJobStatusFinished   = 3 // 已完成
+)
+
+// 异步任务的结果
+type BatchResult struct {
+	Status     int    `json:"status"`     // 任务状态, 整型, 1表示任务开始, 2表示任务进行中, 3表示任务已完成
+	Type       string `json:"type"`       // 操作类型, 字节串, 目前分别有: sync_user(增量更新成员), replace_user(全量覆盖成员), replace_party(全量覆盖部门)
+	Total      int    `json:"total"`      // 任务运行总条数
+	Percentage int    `json:"percentage"` // 目前运行百分比, 当任务完成时为100
+	RemainTime int    `json:"remaintime"` // 预估剩余时间(单位:分钟), 当任务完成时为0
+
+	// 详细的处理结果, 具体格式参考文档, 不同的 Type 格式不一样
+	Result []struct {
+		UserId  string `json:"userid,omitempty"` // sync_user, replace_user
+		Action  int    `json:"action"`           // replace_party
+		PartyId int64  `json:"partyid"`          // replace_party
+		ErrCode int    `json:"errcode"`
+		ErrMsg  string `json:"errmsg"`
+	} `json:"result"`
+}
+
+// 获取异步任务结果.
+func (clt *Client) BatchGetResult(jobId string) (rslt *BatchResult, err error) {
+	if jobId == "" {
+		err = errors.New("empty jobId")
+		return
+	}
+
+	var result struct {
+		corp.Error
+		BatchResult
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/batch/getresult?jobid=" +
+		url.QueryEscape(jobId) + "&access_token="
+	if err = ((*corp.Client)(clt)).GetJSON(incompleteURL, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	rslt = &result.BatchResult
+	return
+}
@@ -0,0 +1,86 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package addresslist
+
+import (
+	"errors"
+
+	"github.com/chanxuehong/wechat/corp"
+)
+
+// userid转换成openid.
+//  该接口使用场景为微信支付, 微信红包和企业转账, 企业号用户在使用微信支付的功能时,
+//  需要自行将企业号的userid转成openid.
+//  userId:  企业号内的成员id
+//  agentId: 非必须; 整型, 需要发送红包的应用ID, 若只是使用微信支付和企业转账, 则无需该参数, 传入 0 即可
+//  返回的 appId 为应用的 appid, 若请求包中不包含 agentid 则不返回 appid.
+func (clt *Client) ConvertToOpenId(userId string, agentId int64) (openId, appId string, err error) {
+	if userId == "" {
+		err = errors.New("empty userId")
+		return
+	}
+
+	var request = struct {
+		UserId  string `json:"userid"`
+		AgentId int64  `json:"agentid,omitempty"`
+	}{
+		UserId:  userId,
+		AgentId: agentId,
+	}
+
+	var result struct {
+		corp.Error
+		OpenId string `json:"openid"`
+		AppId  string `json:"appid"`
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/user/convert_to_openid?access_token="
+	if err = ((*corp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	openId = result.OpenId
+	appId = result.AppId
+	return
+}
+
+// openid转换成userid.
+//  该接口主要应用于使用微信支付, 微信红包和企业转账之后的结果查询, 开发者需要知道某个结果事件的openid对应企业号内成员的信息时,
+//  可以通过调用该接口进行转换查询.
+//  openId: 在使用微信支付, 微信红包和企业转账之后, 返回结果的openid
+func (clt *Client) ConvertToUserId(openId string) (userId string, err error) {
+	if openId == "" {
+		err = errors.New("empty openId")
+		return
+	}
+
+	var request = struct {
+		OpenId string `json:"openid"`
+	}{
+		OpenId: openId,
+	}
+
+	var result struct {
+		corp.Error
+		UserId string `json:"userid"`
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/user/convert_to_userid?access_token="
+	if err = ((*corp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	userId = result.UserId
+	return
+}
@@ -35,7 +35,8 @@ func NewClient(srv corp.AccessTokenServer, clt *http.Client) *Client {
 }
 
 type UserInfo struct {
-	UserId   string `json:"UserId"`   // 员工UserID
+	UserId   string `json:"UserId"`   // 员工UserID, 非企业成员授权时为空
+	OpenId   string `json:"OpenId"`   // 非企业成员的标识, 对当前企业号唯一, 企业成员授权时为空
 	DeviceId string `json:"DeviceId"` // 手机设备号(由微信在安装时随机生成)
 }
 
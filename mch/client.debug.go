@@ -30,6 +30,9 @@ func (pxy *Proxy) AppId() string {
 func (pxy *Proxy) MchId() string {
 	return pxy.mchId
 }
+func (pxy *Proxy) APIKey() string {
+	return pxy.apiKey
+}
 
 // 创建一个新的 Proxy.
 //  如果 httpClient == nil 则默认用 http.DefaultClient.
@@ -107,15 +110,8 @@ func (pxy *Proxy) PostXML(url string, req map[string]string) (resp map[string]st
 		return
 	}
 
-	// 认证签名
-	signature1, ok := resp["sign"]
-	if !ok {
-		err = errors.New("no sign parameter")
-		return
-	}
-	signature2 := Sign(resp, pxy.apiKey, nil)
-	if signature1 != signature2 {
-		err = fmt.Errorf("check signature failed, \r\ninput: %q, \r\nlocal: %q", signature1, signature2)
+	// 认证签名, 返回结果的签名算法和请求的一致
+	if err = CheckSign(resp, pxy.apiKey, req["sign_type"]); err != nil {
 		return
 	}
 	return
@@ -18,3 +18,26 @@ type Error struct {
 func (e *Error) Error() string {
 	return fmt.Sprintf("return_code: %q, return_msg: %q", e.ReturnCode, e.ReturnMsg)
 }
+
+// 业务结果错误, result_code != SUCCESS.
+type BizError struct {
+	ResultCode string `xml:"result_code"            json:"result_code"`
+	ErrCode    string `xml:"err_code,omitempty"     json:"err_code,omitempty"`
+	ErrCodeDes string `xml:"err_code_des,omitempty" json:"err_code_des,omitempty"`
+}
+
+func (e *BizError) Error() string {
+	return fmt.Sprintf("result_code: %q, err_code: %q, err_code_des: %q", e.ResultCode, e.ErrCode, e.ErrCodeDes)
+}
+
+// 检查业务结果, 如果 resp["result_code"] != ResultCodeSuccess 则返回 *BizError.
+func CheckResultCode(resp map[string]string) error {
+	if resultCode := resp["result_code"]; resultCode != ResultCodeSuccess {
+		return &BizError{
+			ResultCode: resultCode,
+			ErrCode:    resp["err_code"],
+			ErrCodeDes: resp["err_code_des"],
+		}
+	}
+	return nil
+}
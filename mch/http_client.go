@@ -18,6 +18,19 @@ func NewTLSHttpClient(certFile, keyFile string) (httpClient *http.Client, err er
 	if err != nil {
 		return
 	}
+	return newTLSHttpClient(cert), nil
+}
+
+// NewTLSHttpClientFromPEM 创建支持双向证书认证的 http.Client, 证书和私钥为 PEM 编码的数据.
+func NewTLSHttpClientFromPEM(certPEMBlock, keyPEMBlock []byte) (httpClient *http.Client, err error) {
+	cert, err := tls.X509KeyPair(certPEMBlock, keyPEMBlock)
+	if err != nil {
+		return
+	}
+	return newTLSHttpClient(cert), nil
+}
+
+func newTLSHttpClient(cert tls.Certificate) (httpClient *http.Client) {
 	tlsConfig := &tls.Config{
 		Certificates: []tls.Certificate{cert},
 	}
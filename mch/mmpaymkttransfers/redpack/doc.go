@@ -0,0 +1,8 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+// 现金红包接口, mmpaymkttransfers 里红包接口的强类型封装.
+//  NOTE: 请求需要双向证书, mch.Proxy 的 httpClient 可以通过 mch.NewTLSHttpClient 创建.
+package redpack
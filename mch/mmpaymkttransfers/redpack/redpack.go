@@ -0,0 +1,220 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package redpack
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/chanxuehong/wechat/mch"
+	"github.com/chanxuehong/wechat/mch/mmpaymkttransfers"
+)
+
+const (
+	SceneIdProduct1 = "PRODUCT_1" // 商品促销
+	SceneIdProduct2 = "PRODUCT_2" // 抽奖
+	SceneIdProduct3 = "PRODUCT_3" // 虚拟物品兑奖
+	SceneIdProduct4 = "PRODUCT_4" // 企业内部福利
+	SceneIdProduct5 = "PRODUCT_5" // 渠道分润
+	SceneIdProduct6 = "PRODUCT_6" // 保险回馈
+	SceneIdProduct7 = "PRODUCT_7" // 彩票派奖
+	SceneIdProduct8 = "PRODUCT_8" // 税务刮奖
+)
+
+// 发放红包的参数.
+type SendParameters struct {
+	MchBillNo   string // 必须; 商户订单号, 每个订单号必须唯一, 组成: mch_id+yyyymmdd+10位一天内不能重复的数字
+	SendName    string // 必须; 红包发送者名称
+	ReOpenId    string // 必须; 接受红包的用户在 wxappid 下的 openid
+	TotalAmount int    // 必须; 付款金额, 单位分
+	TotalNum    int    // 必须; 红包发放总人数, 普通红包为 1, 裂变红包为 3-20
+	Wishing     string // 必须; 红包祝福语
+	ClientIp    string // 普通红包必须; 调用接口的机器 Ip 地址
+	ActName     string // 必须; 活动名称
+	Remark      string // 必须; 备注信息
+	SceneId     string // 非必须; 场景id, 发放红包使用场景, 红包金额大于200时必传
+	RiskInfo    string // 非必须; 活动信息, urlencode 后的 posttime, mobile, deviceid, clientversion 等
+}
+
+// 发放红包的结果.
+type SendResult struct {
+	MchBillNo   string // 商户订单号
+	ReOpenId    string // 接受红包的用户
+	TotalAmount int    // 付款金额, 单位分
+	SendListId  string // 红包订单的微信单号
+}
+
+// 发放普通红包.
+func Send(pxy *mch.Proxy, para *SendParameters) (rslt *SendResult, err error) {
+	if para == nil {
+		err = errors.New("nil SendParameters")
+		return
+	}
+
+	req := buildSendRequest(pxy, para)
+	req["client_ip"] = para.ClientIp
+	req["sign"] = mch.Sign(req, pxy.APIKey(), nil)
+
+	resp, err := mmpaymkttransfers.SendRedPack(pxy, req)
+	if err != nil {
+		return
+	}
+	return parseSendResult(resp)
+}
+
+// 发放裂变红包.
+//  裂变红包的金额随机分配(amt_type=ALL_RAND), para.TotalNum 为 3-20.
+func SendGroup(pxy *mch.Proxy, para *SendParameters) (rslt *SendResult, err error) {
+	if para == nil {
+		err = errors.New("nil SendParameters")
+		return
+	}
+
+	req := buildSendRequest(pxy, para)
+	req["amt_type"] = "ALL_RAND"
+	req["sign"] = mch.Sign(req, pxy.APIKey(), nil)
+
+	resp, err := mmpaymkttransfers.SendGroupRedPack(pxy, req)
+	if err != nil {
+		return
+	}
+	return parseSendResult(resp)
+}
+
+func buildSendRequest(pxy *mch.Proxy, para *SendParameters) map[string]string {
+	req := map[string]string{
+		"nonce_str":    mch.NonceStr(),
+		"mch_billno":   para.MchBillNo,
+		"mch_id":       pxy.MchId(),
+		"wxappid":      pxy.AppId(),
+		"send_name":    para.SendName,
+		"re_openid":    para.ReOpenId,
+		"total_amount": strconv.Itoa(para.TotalAmount),
+		"total_num":    strconv.Itoa(para.TotalNum),
+		"wishing":      para.Wishing,
+		"act_name":     para.ActName,
+		"remark":       para.Remark,
+	}
+	if para.SceneId != "" {
+		req["scene_id"] = para.SceneId
+	}
+	if para.RiskInfo != "" {
+		req["risk_info"] = para.RiskInfo
+	}
+	return req
+}
+
+func parseSendResult(resp map[string]string) (rslt *SendResult, err error) {
+	if err = mch.CheckResultCode(resp); err != nil {
+		return
+	}
+
+	totalAmount, err := strconv.Atoi(resp["total_amount"])
+	if err != nil {
+		err = fmt.Errorf("invalid total_amount: %q", resp["total_amount"])
+		return
+	}
+	rslt = &SendResult{
+		MchBillNo:   resp["mch_billno"],
+		ReOpenId:    resp["re_openid"],
+		TotalAmount: totalAmount,
+		SendListId:  resp["send_listid"],
+	}
+	return
+}
+
+const (
+	StatusSending   = "SENDING"   // 发放中
+	StatusSent      = "SENT"      // 已发放待领取
+	StatusFailed    = "FAILED"    // 发放失败
+	StatusReceived  = "RECEIVED"  // 已领取
+	StatusRefunding = "RFUND_ING" // 退款中
+	StatusRefund    = "REFUND"    // 已退款
+)
+
+// 红包的查询结果.
+//  NOTE: 裂变红包的领取列表(hblist)是嵌套的 XML, 没有包含在这里.
+type Info struct {
+	MchBillNo    string // 商户订单号
+	DetailId     string // 红包单号
+	Status       string // 红包状态
+	SendType     string // 发放类型, API: 通过API接口发放, UPLOAD: 通过上传文件方式发放, ACTIVITY: 通过活动方式发放
+	HbType       string // 红包类型, GROUP: 裂变红包, NORMAL: 普通红包
+	TotalNum     int    // 红包个数
+	TotalAmount  int    // 红包总金额, 单位分
+	Reason       string // 发送失败原因
+	SendTime     string // 红包发送时间
+	RefundTime   string // 红包退款时间
+	RefundAmount int    // 红包退款金额, 单位分
+	Wishing      string // 祝福语
+	Remark       string // 活动描述
+	ActName      string // 活动名称
+}
+
+// 查询红包记录.
+//  mchBillNo: 商户发放红包的商户订单号
+func GetInfo(pxy *mch.Proxy, mchBillNo string) (info *Info, err error) {
+	if mchBillNo == "" {
+		err = errors.New("empty mchBillNo")
+		return
+	}
+
+	req := map[string]string{
+		"nonce_str":  mch.NonceStr(),
+		"mch_billno": mchBillNo,
+		"mch_id":     pxy.MchId(),
+		"appid":      pxy.AppId(),
+		"bill_type":  "MCHT",
+	}
+	req["sign"] = mch.Sign(req, pxy.APIKey(), nil)
+
+	resp, err := mmpaymkttransfers.GetRedPackInfo(pxy, req)
+	if err != nil {
+		return
+	}
+	if err = mch.CheckResultCode(resp); err != nil {
+		return
+	}
+
+	rslt := &Info{
+		MchBillNo:  resp["mch_billno"],
+		DetailId:   resp["detail_id"],
+		Status:     resp["status"],
+		SendType:   resp["send_type"],
+		HbType:     resp["hb_type"],
+		Reason:     resp["reason"],
+		SendTime:   resp["send_time"],
+		RefundTime: resp["refund_time"],
+		Wishing:    resp["wishing"],
+		Remark:     resp["remark"],
+		ActName:    resp["act_name"],
+	}
+	if rslt.TotalNum, err = atoi(resp, "total_num"); err != nil {
+		return
+	}
+	if rslt.TotalAmount, err = atoi(resp, "total_amount"); err != nil {
+		return
+	}
+	if rslt.RefundAmount, err = atoi(resp, "refund_amount"); err != nil {
+		return
+	}
+	info = rslt
+	return
+}
+
+// 解析整数参数, 参数不存在则返回 0.
+func atoi(resp map[string]string, key string) (n int, err error) {
+	str := resp[key]
+	if str == "" {
+		return
+	}
+	if n, err = strconv.Atoi(str); err != nil {
+		err = fmt.Errorf("invalid %s: %q", key, str)
+		return
+	}
+	return
+}
@@ -0,0 +1,74 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mch
+
+import (
+	"net/http"
+
+	"github.com/chanxuehong/util"
+)
+
+// 扫码原生支付模式1的回调处理函数.
+//  productId, openId 为微信服务器回调的参数, 一般需要根据它们调用统一下单接口(trade_type=NATIVE);
+//  成功返回统一下单得到的 prepayId, 失败返回 errCodeDes, errCodeDes 会展示给用户.
+type NativePayHandlerFunc func(r *Request, productId, openId string) (prepayId, errCodeDes string)
+
+var _ MessageHandler = (*NativePayHandler)(nil)
+
+// 扫码原生支付模式1的回调处理器, 根据 NativePayHandlerFunc 的结果回复 prepay_id 给微信服务器.
+//  用于 NewDefaultServer, DefaultServer 已经验证了回调的签名.
+type NativePayHandler struct {
+	appId   string
+	mchId   string
+	apiKey  string
+	handler NativePayHandlerFunc
+}
+
+func NewNativePayHandler(appId, mchId, apiKey string, handler NativePayHandlerFunc) *NativePayHandler {
+	if handler == nil {
+		panic("nil NativePayHandlerFunc")
+	}
+
+	return &NativePayHandler{
+		appId:   appId,
+		mchId:   mchId,
+		apiKey:  apiKey,
+		handler: handler,
+	}
+}
+
+func (h *NativePayHandler) ServeMessage(w http.ResponseWriter, r *Request) {
+	var prepayId, errCodeDes string
+
+	productId := r.Msg["product_id"]
+	openId := r.Msg["openid"]
+	if productId == "" {
+		errCodeDes = "no product_id parameter"
+	} else {
+		prepayId, errCodeDes = h.handler(r, productId, openId)
+		if prepayId == "" && errCodeDes == "" {
+			errCodeDes = "no prepay_id"
+		}
+	}
+
+	resp := map[string]string{
+		"return_code": ReturnCodeSuccess,
+		"appid":       h.appId,
+		"mch_id":      h.mchId,
+		"nonce_str":   NonceStr(),
+	}
+	if errCodeDes == "" {
+		resp["prepay_id"] = prepayId
+		resp["result_code"] = ResultCodeSuccess
+	} else {
+		resp["result_code"] = ResultCodeFail
+		resp["err_code_des"] = errCodeDes
+	}
+	resp["sign"] = Sign(resp, h.apiKey, nil)
+
+	w.Header().Set("Content-Type", "text/xml; charset=utf-8")
+	util.FormatMapToXML(w, resp)
+}
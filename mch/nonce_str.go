@@ -0,0 +1,18 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mch
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// 生成 32 字节的随机字符串, 用于 nonce_str 参数.
+func NonceStr() string {
+	var buf [16]byte
+	rand.Read(buf[:])
+	return hex.EncodeToString(buf[:])
+}
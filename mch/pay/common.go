@@ -34,12 +34,13 @@ func CloseOrder(pxy *mch.Proxy, req map[string]string) (resp map[string]string,
 }
 
 // 申请退款.
-//  NOTE: 请求需要双向证书.
+//  NOTE: 请求需要双向证书, pxy 的 httpClient 可以通过 mch.NewTLSHttpClient 创建.
 func Refund(pxy *mch.Proxy, req map[string]string) (resp map[string]string, err error) {
 	return pxy.PostXML("https://api.mch.weixin.qq.com/secapi/pay/refund", req)
 }
 
 // 查询退款.
+//  一笔订单可能对应多笔退款记录, 可以通过 ParseRefundRecords 解析.
 func RefundQuery(pxy *mch.Proxy, req map[string]string) (resp map[string]string, err error) {
 	return pxy.PostXML("https://api.mch.weixin.qq.com/pay/refundquery", req)
 }
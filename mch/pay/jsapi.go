@@ -0,0 +1,60 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package pay
+
+import (
+	"errors"
+
+	"github.com/chanxuehong/wechat/mch"
+)
+
+// 统一下单并返回预支付交易会话标识 prepay_id.
+//  和 UnifiedOrder 不同的是, 业务结果 result_code != SUCCESS 的时候返回 *mch.BizError.
+func UnifiedOrderPrepayId(pxy *mch.Proxy, req map[string]string) (prepayId string, err error) {
+	resp, err := UnifiedOrder(pxy, req)
+	if err != nil {
+		return
+	}
+	if err = mch.CheckResultCode(resp); err != nil {
+		return
+	}
+
+	prepayId = resp["prepay_id"]
+	if prepayId == "" {
+		err = errors.New("no prepay_id parameter")
+		return
+	}
+	return
+}
+
+// 生成公众号 jsapi 支付需要的参数, 用于 WeixinJSBridge.invoke("getBrandWCPayRequest", ...) 或者 wx.chooseWXPay.
+//  返回的参数包括 appId, timeStamp, nonceStr, package, signType, paySign;
+//  signType 为 "" 时默认为 mch.SignTypeMD5, 必须和统一下单的 sign_type 一致.
+//  NOTE: wx.chooseWXPay 的时间戳参数名为 timestamp(全小写), 请注意转换.
+func JSAPIParameters(appId, timeStamp, nonceStr, prepayId, signType, apiKey string) (parameters map[string]string, err error) {
+	if prepayId == "" {
+		err = errors.New("empty prepayId")
+		return
+	}
+	if signType == "" {
+		signType = mch.SignTypeMD5
+	}
+
+	parameters = map[string]string{
+		"appId":     appId,
+		"timeStamp": timeStamp,
+		"nonceStr":  nonceStr,
+		"package":   "prepay_id=" + prepayId,
+		"signType":  signType,
+	}
+	signature, err := mch.SignWithType(parameters, apiKey, signType)
+	if err != nil {
+		parameters = nil
+		return
+	}
+	parameters["paySign"] = signature
+	return
+}
@@ -0,0 +1,40 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package pay
+
+import (
+	"errors"
+
+	"github.com/chanxuehong/wechat/mch"
+)
+
+// 扫码原生支付模式2, 统一下单(trade_type=NATIVE)并返回二维码链接 code_url.
+//  code_url 有效期为2小时, 可以用 mch.NativeURL2 生成二维码内容.
+func UnifiedOrderCodeURL(pxy *mch.Proxy, req map[string]string) (codeURL string, err error) {
+	if req == nil {
+		err = errors.New("nil request req")
+		return
+	}
+	if tradeType := req["trade_type"]; tradeType != "NATIVE" {
+		err = errors.New("trade_type must be NATIVE, now is " + tradeType)
+		return
+	}
+
+	resp, err := UnifiedOrder(pxy, req)
+	if err != nil {
+		return
+	}
+	if err = mch.CheckResultCode(resp); err != nil {
+		return
+	}
+
+	codeURL = resp["code_url"]
+	if codeURL == "" {
+		err = errors.New("no code_url parameter")
+		return
+	}
+	return
+}
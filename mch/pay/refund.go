@@ -0,0 +1,90 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package pay
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+const (
+	RefundStatusSuccess    = "SUCCESS"     // 退款成功
+	RefundStatusFail       = "FAIL"        // 退款失败
+	RefundStatusProcessing = "PROCESSING"  // 退款处理中
+	RefundStatusNotSure    = "NOTSURE"     // 未确定, 需要商户原退款单号重新发起
+	RefundStatusChange     = "CHANGE"      // 转入代发, 退款到银行发现用户的卡作废或者冻结了, 导致原路退款银行卡失败
+	RefundStatusClose      = "REFUNDCLOSE" // 退款关闭
+)
+
+// 查询退款返回的一笔退款记录.
+type RefundRecord struct {
+	OutRefundNo       string // 商户退款单号
+	RefundId          string // 微信退款单号
+	RefundChannel     string // 退款渠道, ORIGINAL—原路退款, BALANCE—退回到余额
+	RefundFee         int64  // 退款总金额, 单位为分
+	CouponRefundFee   int64  // 代金券或立减优惠退款金额, 单位为分
+	RefundStatus      string // 退款状态
+	RefundRecvAccount string // 退款入账账户
+	RefundSuccessTime string // 退款成功时间
+}
+
+// 从查询退款的返回结果中解析出全部的退款记录.
+//  resp 为 RefundQuery 的返回结果, 退款记录的参数是以 _$n 为后缀的, $n 从 0 开始.
+func ParseRefundRecords(resp map[string]string) (records []RefundRecord, err error) {
+	countStr, ok := resp["refund_count"]
+	if !ok {
+		err = errors.New("no refund_count parameter")
+		return
+	}
+	count, err := strconv.Atoi(countStr)
+	if err != nil {
+		err = fmt.Errorf("invalid refund_count: %q", countStr)
+		return
+	}
+	// 每一笔退款记录至少对应 resp 里的一个参数, 超过 len(resp) 的 refund_count 肯定是错误的,
+	// 不能直接用来分配内存.
+	if count < 0 || count > len(resp) {
+		err = fmt.Errorf("invalid refund_count: %q", countStr)
+		return
+	}
+
+	records = make([]RefundRecord, count)
+	for i := 0; i < count; i++ {
+		suffix := "_" + strconv.Itoa(i)
+		record := &records[i]
+
+		record.OutRefundNo = resp["out_refund_no"+suffix]
+		record.RefundId = resp["refund_id"+suffix]
+		record.RefundChannel = resp["refund_channel"+suffix]
+		record.RefundStatus = resp["refund_status"+suffix]
+		record.RefundRecvAccount = resp["refund_recv_accout"+suffix] // 微信文档里就是 accout
+		record.RefundSuccessTime = resp["refund_success_time"+suffix]
+
+		if record.RefundFee, err = parseFee(resp, "refund_fee"+suffix); err != nil {
+			records = nil
+			return
+		}
+		if record.CouponRefundFee, err = parseFee(resp, "coupon_refund_fee"+suffix); err != nil {
+			records = nil
+			return
+		}
+	}
+	return
+}
+
+// 解析金额参数, 参数不存在则返回 0.
+func parseFee(resp map[string]string, key string) (fee int64, err error) {
+	str, ok := resp[key]
+	if !ok || str == "" {
+		return
+	}
+	if fee, err = strconv.ParseInt(str, 10, 64); err != nil {
+		err = fmt.Errorf("invalid %s: %q", key, str)
+		return
+	}
+	return
+}
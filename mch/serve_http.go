@@ -52,15 +52,7 @@ func ServeHTTP(w http.ResponseWriter, r *http.Request, queryValues url.Values, s
 			}
 
 			// 认证签名
-			signature1, ok := msg["sign"]
-			if !ok {
-				err = errors.New("no sign parameter")
-				errHandler.ServeError(w, r, err)
-				return
-			}
-			signature2 := Sign(msg, srv.APIKey(), nil)
-			if !security.SecureCompareString(signature1, signature2) {
-				err = fmt.Errorf("check signature failed, \r\ninput: %q, \r\nlocal: %q", signature1, signature2)
+			if err = CheckSign(msg, srv.APIKey(), SignTypeMD5); err != nil {
 				errHandler.ServeError(w, r, err)
 				return
 			}
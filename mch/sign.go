@@ -7,11 +7,22 @@ package mch
 
 import (
 	"bytes"
+	"crypto/hmac"
 	"crypto/md5"
 	"crypto/sha1"
+	"crypto/sha256"
 	"encoding/hex"
+	"errors"
+	"fmt"
 	"hash"
 	"sort"
+
+	"github.com/chanxuehong/util/security"
+)
+
+const (
+	SignTypeMD5        = "MD5"
+	SignTypeHMACSHA256 = "HMAC-SHA256"
 )
 
 // 微信支付签名.
@@ -57,6 +68,44 @@ func Sign(parameters map[string]string, apiKey string, fn func() hash.Hash) stri
 	return string(bytes.ToUpper(signature))
 }
 
+// 按照 signType 指定的签名算法签名.
+//  signType 为 "" 或 SignTypeMD5 时用 MD5, 为 SignTypeHMACSHA256 时用 HMAC-SHA256.
+func SignWithType(parameters map[string]string, apiKey, signType string) (signature string, err error) {
+	switch signType {
+	case "", SignTypeMD5:
+		signature = Sign(parameters, apiKey, md5.New)
+	case SignTypeHMACSHA256:
+		signature = Sign(parameters, apiKey, func() hash.Hash {
+			return hmac.New(sha256.New, []byte(apiKey))
+		})
+	default:
+		err = fmt.Errorf("unsupported sign_type: %q", signType)
+	}
+	return
+}
+
+// 验证 parameters 里的签名(sign).
+//  签名算法由 parameters["sign_type"] 决定, 如果没有 sign_type 参数则用 defaultSignType, 参考 SignWithType.
+func CheckSign(parameters map[string]string, apiKey, defaultSignType string) (err error) {
+	signature1, ok := parameters["sign"]
+	if !ok {
+		return errors.New("no sign parameter")
+	}
+
+	signType, ok := parameters["sign_type"]
+	if !ok {
+		signType = defaultSignType
+	}
+	signature2, err := SignWithType(parameters, apiKey, signType)
+	if err != nil {
+		return
+	}
+	if !security.SecureCompareString(signature1, signature2) {
+		return fmt.Errorf("check signature failed, \r\ninput: %q, \r\nlocal: %q", signature1, signature2)
+	}
+	return
+}
+
 // 收货地址共享接口签名
 func EditAddressSign(appId, url, timestamp, nonceStr, accessToken string) string {
 	h := sha1.New()
@@ -75,3 +75,64 @@ func Sign2(parameters map[string]string, apiKey string, fn func() hash.Hash) str
 	hex.Encode(signature, h.Sum(nil))
 	return string(bytes.ToUpper(signature))
 }
+
+// 微信支付文档(安全规范, 签名算法)里的例子
+var (
+	testDocSignParameters = map[string]string{
+		"appid":       "wxd930ea5d5a258f4f",
+		"mch_id":      "10000100",
+		"device_info": "1000",
+		"body":        "test",
+		"nonce_str":   "ibuaiVcKdpRxkhJA",
+	}
+
+	testDocAPIKey = "192006250b4c09247ec02edce69f6a2d"
+)
+
+func TestSignWithType(t *testing.T) {
+	tests := []struct {
+		signType  string
+		signature string
+	}{
+		{"", "9A0A8659F005D6984697E2CA0A9CF3B7"},
+		{SignTypeMD5, "9A0A8659F005D6984697E2CA0A9CF3B7"},
+		{SignTypeHMACSHA256, "6A9AE1657590FD6257D693A078E1C3E4BB6BA4DC30B23E0EE2496E54170DACD6"},
+	}
+	for _, test := range tests {
+		signature, err := SignWithType(testDocSignParameters, testDocAPIKey, test.signType)
+		if err != nil {
+			t.Errorf("sign_type %q: %s", test.signType, err)
+			continue
+		}
+		if signature != test.signature {
+			t.Errorf("sign_type %q:\nhave %s\nwant %s", test.signType, signature, test.signature)
+		}
+	}
+
+	if _, err := SignWithType(testDocSignParameters, testDocAPIKey, "SHA1"); err == nil {
+		t.Error("sign_type SHA1: want error, have nil")
+	}
+}
+
+func TestCheckSign(t *testing.T) {
+	parameters := make(map[string]string, len(testDocSignParameters)+2)
+	for k, v := range testDocSignParameters {
+		parameters[k] = v
+	}
+	parameters["sign_type"] = SignTypeHMACSHA256
+	parameters["sign"] = "6A9AE1657590FD6257D693A078E1C3E4BB6BA4DC30B23E0EE2496E54170DACD6"
+
+	// sign_type 不为空的时候也要参与签名, 所以和文档里的签名不一样
+	if err := CheckSign(parameters, testDocAPIKey, SignTypeMD5); err == nil {
+		t.Error("CheckSign: want error, have nil")
+	}
+
+	delete(parameters, "sign_type")
+	if err := CheckSign(parameters, testDocAPIKey, SignTypeHMACSHA256); err != nil {
+		t.Error(err)
+	}
+	parameters["sign"] = "9A0A8659F005D6984697E2CA0A9CF3B7"
+	if err := CheckSign(parameters, testDocAPIKey, ""); err != nil {
+		t.Error(err)
+	}
+}
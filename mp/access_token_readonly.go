@@ -0,0 +1,116 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// 只读模式下没有可用的 access_token(没有设置或者已经过期), 或者 access_token 失效后没有获取到新的.
+var ErrAccessTokenUnavailable = errors.New("access_token is unavailable in read-only mode")
+
+// 从外部(比如统一的中控服务)获取 access_token 和它的过期时间.
+//  只读取, 不能到微信服务器刷新 access_token.
+type TokenGetter func() (token string, expiresAt time.Time, err error)
+
+var _ AccessTokenServer = (*ReadOnlyAccessTokenServer)(nil)
+
+// 只读的 AccessTokenServer, 自己从不调用微信服务器的 access_token 接口.
+//  用于 access_token 由统一的中控服务获取的部署环境, access_token 通过 SetToken 注入, 或者通过 TokenGetter 读取.
+//
+//  TokenRefresh(access_token 失效时调用) 不会到微信服务器刷新, 而是调用 TokenGetter 读取一次,
+//  如果读取到的还是原来的 access_token(或者没有 TokenGetter), 则立即返回 ErrAccessTokenUnavailable.
+type ReadOnlyAccessTokenServer struct {
+	getter TokenGetter
+
+	mutex     sync.RWMutex
+	token     string
+	expiresAt time.Time
+}
+
+// 创建一个新的 ReadOnlyAccessTokenServer, getter 可以为 nil, 这时只能通过 SetToken 设置 access_token.
+func NewReadOnlyAccessTokenServer(getter TokenGetter) *ReadOnlyAccessTokenServer {
+	return &ReadOnlyAccessTokenServer{
+		getter: getter,
+	}
+}
+
+func (srv *ReadOnlyAccessTokenServer) TagCE90001AFE9C11E48611A4DB30FED8E1() {}
+
+// 设置 access_token 和它的过期时间, expiresAt.IsZero() 表示不过期.
+func (srv *ReadOnlyAccessTokenServer) SetToken(token string, expiresAt time.Time) {
+	srv.mutex.Lock()
+	srv.token = token
+	srv.expiresAt = expiresAt
+	srv.mutex.Unlock()
+}
+
+func (srv *ReadOnlyAccessTokenServer) Token() (token string, err error) {
+	srv.mutex.RLock()
+	token, expiresAt := srv.token, srv.expiresAt
+	srv.mutex.RUnlock()
+
+	if token != "" && (expiresAt.IsZero() || time.Now().Before(expiresAt)) {
+		return
+	}
+	// 过期了, TokenGetter 返回同一个 access_token 但是延长了过期时间也是可以的
+	return srv.get("")
+}
+
+func (srv *ReadOnlyAccessTokenServer) TokenRefresh() (token string, err error) {
+	srv.mutex.RLock()
+	token = srv.token
+	srv.mutex.RUnlock()
+
+	return srv.get(token)
+}
+
+// 通过 TokenGetter 获取一个没有过期的 access_token, invalidToken 不为空时还必须和 invalidToken 不同.
+func (srv *ReadOnlyAccessTokenServer) get(invalidToken string) (token string, err error) {
+	if srv.getter == nil {
+		return "", ErrAccessTokenUnavailable
+	}
+
+	token, expiresAt, err := srv.getter()
+	if err != nil {
+		return "", err
+	}
+	if token == "" || (invalidToken != "" && token == invalidToken) || (!expiresAt.IsZero() && !time.Now().Before(expiresAt)) {
+		return "", ErrAccessTokenUnavailable
+	}
+
+	srv.SetToken(token, expiresAt)
+	return
+}
+
+// Client.SetToken 不能替换 Client 已经在使用的其他 AccessTokenServer.
+var ErrAccessTokenServerNotReadOnly = errors.New("SetToken: AccessTokenServer is not a ReadOnlyAccessTokenServer")
+
+// 手动设置 access_token 和它的过期时间, expiresAt.IsZero() 表示不过期.
+//  如果 clt.AccessTokenServer == nil, 则设置为一个没有 TokenGetter 的 ReadOnlyAccessTokenServer,
+//  之后 clt 不会到微信服务器获取 access_token, access_token 失效的时候直接返回 ErrAccessTokenUnavailable.
+//  如果 clt.AccessTokenServer 是其他的 AccessTokenServer(比如 DefaultAccessTokenServer, 它的 tokenDaemon goroutine
+//  没有办法停止, 会继续到微信服务器刷新 access_token), 则返回 ErrAccessTokenServerNotReadOnly;
+//  这种情况请用 NewClient(NewReadOnlyAccessTokenServer(getter), clt) 创建 Client.
+//
+//  NOTE: 设置 AccessTokenServer 不是并发安全的, 请在 clt 开始使用之前调用一次 SetToken;
+//  之后的调用只是更新 access_token, 是并发安全的.
+func (clt *Client) SetToken(token string, expiresAt time.Time) (err error) {
+	var srv *ReadOnlyAccessTokenServer
+	switch v := clt.AccessTokenServer.(type) {
+	case nil:
+		srv = NewReadOnlyAccessTokenServer(nil)
+		clt.AccessTokenServer = srv
+	case *ReadOnlyAccessTokenServer:
+		srv = v
+	default:
+		return ErrAccessTokenServerNotReadOnly
+	}
+	srv.SetToken(token, expiresAt)
+	return
+}
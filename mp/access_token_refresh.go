@@ -0,0 +1,85 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultTokenRefreshRatio  = 0.8  // 默认在 access_token 有效期过去 80% 的时候刷新
+	DefaultTokenRefreshJitter = 0.05 // 默认的随机抖动, 有效期的 5%
+)
+
+// access_token 刷新成功后的回调函数, 可以用来持久化 access_token.
+//  expiresAt 是 access_token 的过期时间(已经留了缓冲区).
+//  NOTE: 回调函数在刷新 access_token 的 goroutine 里同步调用, 不要做耗时的操作.
+type TokenRefreshedFunc func(token string, expiresAt time.Time)
+
+// DefaultAccessTokenServer 提前刷新 access_token 的策略.
+//  如果正好在 access_token 过期的时候才刷新, 那么过期到刷新成功之间的请求都会失败(然后重试),
+//  所以在有效期过去 ratio 的时候就提前刷新, 并且再提前一个 [0, ttl*jitter) 的随机时间,
+//  防止多个实例在同一时刻刷新.
+type tokenRefreshPolicy struct {
+	sync.Mutex
+	ratio       float64
+	jitter      float64
+	onRefreshed TokenRefreshedFunc
+}
+
+// 根据 access_token 的有效时间(秒)计算距离下一次刷新的时间.
+func (policy *tokenRefreshPolicy) refreshInterval(expiresIn int64) time.Duration {
+	policy.Lock()
+	ratio, jitter := policy.ratio, policy.jitter
+	policy.Unlock()
+
+	if ratio <= 0 || ratio > 1 {
+		ratio = DefaultTokenRefreshRatio
+	}
+	if jitter < 0 || jitter >= ratio {
+		jitter = DefaultTokenRefreshJitter
+	}
+
+	ttl := time.Duration(expiresIn) * time.Second
+	interval := time.Duration(float64(ttl) * ratio)
+	if n := int64(float64(ttl) * jitter); n > 0 {
+		interval -= time.Duration(rand.Int63n(n))
+	}
+	if interval < time.Second {
+		interval = time.Second
+	}
+	return interval
+}
+
+func (policy *tokenRefreshPolicy) refreshed(token string, expiresIn int64) {
+	policy.Lock()
+	fn := policy.onRefreshed
+	policy.Unlock()
+
+	if fn != nil {
+		fn(token, time.Now().Add(time.Duration(expiresIn)*time.Second))
+	}
+}
+
+// 设置提前刷新 access_token 的策略.
+//  ratio:  在 access_token 有效期过去多少比例的时候刷新, 取值 (0, 1], 其他值使用 DefaultTokenRefreshRatio
+//  jitter: 再随机提前有效期的 [0, jitter) 比例的时间, 取值 [0, ratio), 其他值使用 DefaultTokenRefreshJitter
+//  新的策略在下一次刷新 access_token 后生效.
+func (srv *DefaultAccessTokenServer) SetRefreshPolicy(ratio, jitter float64) {
+	srv.refreshPolicy.Lock()
+	srv.refreshPolicy.ratio = ratio
+	srv.refreshPolicy.jitter = jitter
+	srv.refreshPolicy.Unlock()
+}
+
+// 设置 access_token 刷新成功后的回调函数, fn == nil 表示取消.
+func (srv *DefaultAccessTokenServer) OnTokenRefreshed(fn TokenRefreshedFunc) {
+	srv.refreshPolicy.Lock()
+	srv.refreshPolicy.onRefreshed = fn
+	srv.refreshPolicy.Unlock()
+}
@@ -43,12 +43,15 @@ var _ AccessTokenServer = (*DefaultAccessTokenServer)(nil)
 //  1. 用于单进程环境.
 //  2. 因为 DefaultAccessTokenServer 同时也是一个简单的中控服务器, 而不是仅仅实现 AccessTokenServer 接口,
 //     所以整个系统只能存在一个 DefaultAccessTokenServer 实例!
+//  3. 后台 goroutine 会在 access_token 过期之前提前刷新, 见 SetRefreshPolicy, OnTokenRefreshed.
 type DefaultAccessTokenServer struct {
 	appId      string
 	appSecret  string
 	httpClient *http.Client
 
 	resetTickerChan chan time.Duration // 用于重置 tokenDaemon 里的 ticker
+	refreshFlight   singleflight       // 合并并发的 TokenRefresh
+	refreshPolicy   tokenRefreshPolicy // 提前刷新 access_token 的策略
 
 	tokenGet struct {
 		sync.Mutex
@@ -75,6 +78,8 @@ func NewDefaultAccessTokenServer(appId, appSecret string, clt *http.Client) (srv
 		httpClient:      clt,
 		resetTickerChan: make(chan time.Duration),
 	}
+	srv.refreshPolicy.ratio = DefaultTokenRefreshRatio
+	srv.refreshPolicy.jitter = DefaultTokenRefreshJitter
 
 	go srv.tokenDaemon(time.Hour * 24) // 启动 tokenDaemon
 	return
@@ -94,14 +99,22 @@ func (srv *DefaultAccessTokenServer) Token() (token string, err error) {
 }
 
 func (srv *DefaultAccessTokenServer) TokenRefresh() (token string, err error) {
-	accessTokenInfo, cached, err := srv.getToken()
+	// 并发的刷新请求合并为一次, 共享同一个结果
+	value, err := srv.refreshFlight.Do(func() (interface{}, error) {
+		accessTokenInfo, cached, err := srv.getToken()
+		if err != nil {
+			return nil, err
+		}
+		if !cached {
+			srv.resetTickerChan <- srv.refreshPolicy.refreshInterval(accessTokenInfo.ExpiresIn)
+			srv.refreshPolicy.refreshed(accessTokenInfo.Token, accessTokenInfo.ExpiresIn)
+		}
+		return accessTokenInfo.Token, nil
+	})
 	if err != nil {
 		return
 	}
-	if !cached {
-		srv.resetTickerChan <- time.Duration(accessTokenInfo.ExpiresIn) * time.Second
-	}
-	token = accessTokenInfo.Token
+	token = value.(string)
 	return
 }
 
@@ -121,7 +134,9 @@ NEW_TICK_DURATION:
 				break
 			}
 			if !cached {
-				newTickDuration := time.Duration(accessTokenInfo.ExpiresIn) * time.Second
+				srv.refreshPolicy.refreshed(accessTokenInfo.Token, accessTokenInfo.ExpiresIn)
+
+				newTickDuration := srv.refreshPolicy.refreshInterval(accessTokenInfo.ExpiresIn)
 				if tickDuration != newTickDuration {
 					tickDuration = newTickDuration
 					ticker.Stop()
@@ -0,0 +1,27 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+// access_token 的存储接口, 用于多个进程(多台机器)之间共享同一个公众号的 access_token.
+//  see DistributedAccessTokenServer
+type TokenStorage interface {
+	// 获取存储的 access_token 和它的过期时间(unixtime).
+	//  如果没有存储 access_token 则返回 "", 0, nil
+	Get() (token string, expiresAt int64, err error)
+
+	// 存储 access_token 和它的过期时间(unixtime).
+	Put(token string, expiresAt int64) error
+
+	// 获取跨进程的锁, 保证同一时刻只有一个进程到微信服务器刷新 access_token.
+	//  获取成功后返回释放锁的函数 unlock, 调用者必须在操作完成后调用 unlock.
+	Lock() (unlock func(), err error)
+}
+
+// FileTokenStorage, RedisTokenStorage 存储的数据格式
+type storedTokenInfo struct {
+	Token     string `json:"access_token"`
+	ExpiresAt int64  `json:"expires_at"`
+}
@@ -0,0 +1,109 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"time"
+)
+
+var _ TokenStorage = (*FileTokenStorage)(nil)
+
+// 基于本地文件的 TokenStorage 实现.
+//  NOTE: 用于同一台机器上的多个进程共享 access_token.
+type FileTokenStorage struct {
+	filepath string // 存储 access_token 的文件路径, 锁文件为 filepath + ".lock"
+
+	LockWaitTimeout time.Duration // 获取锁的最长等待时间, 默认 10 秒
+	LockStaleAfter  time.Duration // 锁文件超过这个时间没有释放则认为持有者已经崩溃, 默认 30 秒
+}
+
+// 创建一个新的 FileTokenStorage.
+func NewFileTokenStorage(filepath string) *FileTokenStorage {
+	if filepath == "" {
+		panic("empty filepath")
+	}
+	return &FileTokenStorage{
+		filepath:        filepath,
+		LockWaitTimeout: 10 * time.Second,
+		LockStaleAfter:  30 * time.Second,
+	}
+}
+
+func (storage *FileTokenStorage) Get() (token string, expiresAt int64, err error) {
+	data, err := ioutil.ReadFile(storage.filepath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			err = nil
+		}
+		return
+	}
+	if len(data) == 0 {
+		return
+	}
+
+	var info storedTokenInfo
+	if err = json.Unmarshal(data, &info); err != nil {
+		return
+	}
+	token = info.Token
+	expiresAt = info.ExpiresAt
+	return
+}
+
+func (storage *FileTokenStorage) Put(token string, expiresAt int64) (err error) {
+	data, err := json.Marshal(&storedTokenInfo{
+		Token:     token,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return
+	}
+
+	// 先写临时文件再 rename, 保证其他进程不会读到写了一半的文件
+	tmpFilepath := storage.filepath + ".tmp"
+	if err = ioutil.WriteFile(tmpFilepath, data, 0600); err != nil {
+		return
+	}
+	if err = os.Rename(tmpFilepath, storage.filepath); err != nil {
+		os.Remove(tmpFilepath)
+		return
+	}
+	return
+}
+
+func (storage *FileTokenStorage) Lock() (unlock func(), err error) {
+	lockFilepath := storage.filepath + ".lock"
+	deadline := time.Now().Add(storage.LockWaitTimeout)
+
+	for {
+		file, err := os.OpenFile(lockFilepath, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0600)
+		if err == nil {
+			file.Close()
+			unlock = func() {
+				os.Remove(lockFilepath)
+			}
+			return unlock, nil
+		}
+		if !os.IsExist(err) {
+			return nil, err
+		}
+
+		// 持有锁的进程可能已经崩溃, 清除过期的锁文件
+		if fi, err := os.Stat(lockFilepath); err == nil && time.Since(fi.ModTime()) > storage.LockStaleAfter {
+			os.Remove(lockFilepath)
+			continue
+		}
+
+		if time.Now().After(deadline) {
+			return nil, errors.New("timeout waiting for lock file: " + lockFilepath)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
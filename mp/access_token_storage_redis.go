@@ -0,0 +1,137 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// redis 连接的抽象, github.com/garyburd/redigo/redis.Conn 满足这个接口.
+type RedisConn interface {
+	Do(commandName string, args ...interface{}) (reply interface{}, err error)
+	Close() error
+}
+
+var _ TokenStorage = (*RedisTokenStorage)(nil)
+
+// 基于 redis 的 TokenStorage 实现, 用于多台机器共享 access_token.
+type RedisTokenStorage struct {
+	getConn func() RedisConn // 获取 redis 连接, 用完后会调用 Close
+	key     string           // 存储 access_token 的 key, 锁的 key 为 key + ":lock"
+
+	LockWaitTimeout time.Duration // 获取锁的最长等待时间, 默认 10 秒
+	LockExpire      time.Duration // 锁的过期时间, 防止持有锁的进程崩溃导致死锁, 默认 30 秒
+}
+
+// 创建一个新的 RedisTokenStorage.
+//  getConn: 获取 redis 连接的函数, 比如 func() mp.RedisConn { return pool.Get() }
+//  key:     存储 access_token 的 key, 一般和 appid 关联, 比如 "wechat:access_token:" + appId
+func NewRedisTokenStorage(getConn func() RedisConn, key string) *RedisTokenStorage {
+	if getConn == nil {
+		panic("nil getConn")
+	}
+	if key == "" {
+		panic("empty key")
+	}
+	return &RedisTokenStorage{
+		getConn:         getConn,
+		key:             key,
+		LockWaitTimeout: 10 * time.Second,
+		LockExpire:      30 * time.Second,
+	}
+}
+
+func (storage *RedisTokenStorage) Get() (token string, expiresAt int64, err error) {
+	conn := storage.getConn()
+	defer conn.Close()
+
+	reply, err := conn.Do("GET", storage.key)
+	if err != nil {
+		return
+	}
+
+	var data []byte
+	switch v := reply.(type) {
+	case nil:
+		return
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		err = fmt.Errorf("unexpected redis reply type: %T", reply)
+		return
+	}
+
+	var info storedTokenInfo
+	if err = json.Unmarshal(data, &info); err != nil {
+		return
+	}
+	token = info.Token
+	expiresAt = info.ExpiresAt
+	return
+}
+
+func (storage *RedisTokenStorage) Put(token string, expiresAt int64) (err error) {
+	data, err := json.Marshal(&storedTokenInfo{
+		Token:     token,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return
+	}
+
+	conn := storage.getConn()
+	defer conn.Close()
+
+	ttl := expiresAt - time.Now().Unix()
+	if ttl <= 0 {
+		_, err = conn.Do("DEL", storage.key)
+		return
+	}
+	_, err = conn.Do("SET", storage.key, data, "EX", ttl)
+	return
+}
+
+// 只有锁的值和自己设置的值一致才删除, 防止删除别的进程的锁
+const redisUnlockScript = `if redis.call("get", KEYS[1]) == ARGV[1] then return redis.call("del", KEYS[1]) else return 0 end`
+
+func (storage *RedisTokenStorage) Lock() (unlock func(), err error) {
+	var randomBytes [16]byte
+	if _, err = rand.Read(randomBytes[:]); err != nil {
+		return
+	}
+	lockKey := storage.key + ":lock"
+	lockValue := hex.EncodeToString(randomBytes[:])
+	deadline := time.Now().Add(storage.LockWaitTimeout)
+
+	for {
+		conn := storage.getConn()
+		reply, err := conn.Do("SET", lockKey, lockValue, "NX", "PX", int64(storage.LockExpire/time.Millisecond))
+		conn.Close()
+		if err != nil {
+			return nil, err
+		}
+		if reply != nil { // "OK"
+			unlock = func() {
+				conn := storage.getConn()
+				conn.Do("EVAL", redisUnlockScript, 1, lockKey, lockValue)
+				conn.Close()
+			}
+			return unlock, nil
+		}
+
+		if time.Now().After(deadline) {
+			return nil, errors.New("timeout waiting for redis lock: " + lockKey)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
@@ -0,0 +1,16 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+// access_token 中控服务器的 http 服务, see ../access_token_server.png
+//
+//  中控服务器:
+//  handler := accesstokenserver.NewHandler("key")
+//  handler.Register("appid", mp.NewDefaultAccessTokenServer("appid", "appsecret", nil))
+//  http.Handle("/token", handler)
+//
+//  业务服务器:
+//  srv := accesstokenserver.NewTokenGetter("http://token.example.com/token", "appid", "key", nil)
+//  clt := mp.NewClient(srv, nil)
+package accesstokenserver
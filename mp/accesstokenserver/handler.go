@@ -0,0 +1,153 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package accesstokenserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/chanxuehong/util/security"
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 中控服务器返回的数据结构
+type tokenResponse struct {
+	mp.Error
+	Token string `json:"access_token,omitempty"`
+}
+
+// 中控服务器的 http.Handler, 集中管理多个公众号的 access_token.
+//
+//  请求: GET /token?appid=APPID&key=KEY[&refresh=1]
+//  appid:   公众号的 appid
+//  key:     访问中控服务器的密钥, 和 NewHandler 的参数 key 一致
+//  refresh: 为 1 时请求中控服务器到微信服务器刷新 access_token
+//
+//  返回: {"errcode":0,"errmsg":"","access_token":"ACCESS_TOKEN"}
+//  获取失败时 errcode 不为 0, 如果是微信服务器返回的错误, errcode, errmsg 和微信服务器返回的一致.
+//
+//  NOTE:
+//  1. key 在 URL 的查询参数里, 可能被代理或者访问日志记录下来, 请使用 https 或者只在内网访问中控服务器;
+//  2. 同一个公众号的 refresh=1 请求会被合并, 距离上次刷新不到 MinRefreshInterval 的请求直接返回当前的 access_token,
+//     防止业务服务器频繁刷新耗尽每天的 access_token 调用次数.
+type Handler struct {
+	key string
+
+	rwmutex sync.RWMutex
+	servers map[string]*tokenServer // map[appid]*tokenServer
+}
+
+// 同一个公众号两次刷新 access_token 的最小间隔.
+const MinRefreshInterval = 10 * time.Second
+
+type tokenServer struct {
+	mp.AccessTokenServer
+
+	refreshMutex  sync.Mutex // 合并同时到来的 refresh 请求
+	lastRefreshAt time.Time
+}
+
+// 创建一个新的 Handler.
+//  key: 访问中控服务器的密钥, 为空表示不校验, 这种情况下请确保中控服务器只能被内网访问!
+func NewHandler(key string) *Handler {
+	return &Handler{
+		key:     key,
+		servers: make(map[string]*tokenServer),
+	}
+}
+
+// 注册公众号的 AccessTokenServer, 同一个 appId 多次注册后面的覆盖前面的.
+func (handler *Handler) Register(appId string, srv mp.AccessTokenServer) {
+	if appId == "" {
+		panic("empty appId")
+	}
+	if srv == nil {
+		panic("nil AccessTokenServer")
+	}
+
+	handler.rwmutex.Lock()
+	handler.servers[appId] = &tokenServer{AccessTokenServer: srv}
+	handler.rwmutex.Unlock()
+}
+
+// 注销公众号的 AccessTokenServer.
+func (handler *Handler) Unregister(appId string) {
+	handler.rwmutex.Lock()
+	delete(handler.servers, appId)
+	handler.rwmutex.Unlock()
+}
+
+func (handler *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	queryValues := r.URL.Query()
+
+	if handler.key != "" && !security.SecureCompareString(queryValues.Get("key"), handler.key) {
+		http.Error(w, "invalid key", http.StatusForbidden)
+		return
+	}
+
+	appId := queryValues.Get("appid")
+	if appId == "" {
+		http.Error(w, "appid is required", http.StatusBadRequest)
+		return
+	}
+
+	handler.rwmutex.RLock()
+	srv := handler.servers[appId]
+	handler.rwmutex.RUnlock()
+
+	if srv == nil {
+		http.Error(w, "unknown appid: "+appId, http.StatusNotFound)
+		return
+	}
+
+	var (
+		token string
+		err   error
+	)
+	if queryValues.Get("refresh") == "1" {
+		token, err = handler.refresh(srv)
+	} else {
+		token, err = srv.Token()
+	}
+
+	var resp tokenResponse
+	if err != nil {
+		if wechatErr, ok := err.(*mp.Error); ok {
+			resp.Error = *wechatErr
+		} else {
+			resp.ErrCode = -1
+			resp.ErrMsg = err.Error()
+		}
+	} else {
+		resp.Token = token
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(&resp)
+}
+
+// 刷新 access_token, 距离上次刷新不到 MinRefreshInterval 则返回当前的 access_token.
+//  同时到来的请求在 refreshMutex 上排队, 第一个请求刷新后, 后面的请求直接拿到刷新后的 access_token.
+func (handler *Handler) refresh(srv *tokenServer) (token string, err error) {
+	srv.refreshMutex.Lock()
+	defer srv.refreshMutex.Unlock()
+
+	if time.Since(srv.lastRefreshAt) < MinRefreshInterval {
+		return srv.Token()
+	}
+	if token, err = srv.TokenRefresh(); err != nil {
+		return
+	}
+	srv.lastRefreshAt = time.Now()
+	return
+}
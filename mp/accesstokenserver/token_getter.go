@@ -0,0 +1,103 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package accesstokenserver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+var _ mp.AccessTokenServer = (*TokenGetter)(nil)
+
+// 从中控服务器(Handler)获取 access_token 的 mp.AccessTokenServer 实现, 给业务服务器的 mp.Client 使用.
+//  NOTE: TokenGetter 不缓存 access_token, 每次都到中控服务器获取, access_token 的缓存和刷新由中控服务器负责.
+type TokenGetter struct {
+	tokenURL   string // 中控服务器获取 access_token 的 URL, 已经带上了 appid 和 key
+	refreshURL string // 中控服务器刷新 access_token 的 URL, tokenURL 加上 refresh=1
+	httpClient *http.Client
+}
+
+// 创建一个新的 TokenGetter.
+//  serverURL: 中控服务器的地址, 比如 https://token.example.com/token, 可以带有其他的查询参数
+//  key:       访问中控服务器的密钥, 会放在 URL 的查询参数里, 请使用 https 或者只在内网访问中控服务器
+//  如果 clt == nil 则默认使用 http.DefaultClient.
+func NewTokenGetter(serverURL, appId, key string, clt *http.Client) *TokenGetter {
+	if serverURL == "" {
+		panic("empty serverURL")
+	}
+	if appId == "" {
+		panic("empty appId")
+	}
+	if clt == nil {
+		clt = http.DefaultClient
+	}
+
+	u, err := url.Parse(serverURL)
+	if err != nil {
+		panic(err)
+	}
+	queryValues := u.Query()
+	queryValues.Set("appid", appId)
+	if key != "" {
+		queryValues.Set("key", key)
+	}
+	u.RawQuery = queryValues.Encode()
+	tokenURL := u.String()
+
+	queryValues.Set("refresh", "1")
+	u.RawQuery = queryValues.Encode()
+	refreshURL := u.String()
+
+	return &TokenGetter{
+		tokenURL:   tokenURL,
+		refreshURL: refreshURL,
+		httpClient: clt,
+	}
+}
+
+func (getter *TokenGetter) TagCE90001AFE9C11E48611A4DB30FED8E1() {}
+
+func (getter *TokenGetter) Token() (token string, err error) {
+	return getter.getToken(getter.tokenURL)
+}
+
+func (getter *TokenGetter) TokenRefresh() (token string, err error) {
+	return getter.getToken(getter.refreshURL)
+}
+
+func (getter *TokenGetter) getToken(_url string) (token string, err error) {
+	httpResp, err := getter.httpClient.Get(_url)
+	if err != nil {
+		return
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		err = fmt.Errorf("http.Status: %s", httpResp.Status)
+		return
+	}
+
+	var result tokenResponse
+	if err = json.NewDecoder(httpResp.Body).Decode(&result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	if result.Token == "" {
+		err = errors.New("empty access_token")
+		return
+	}
+	token = result.Token
+	return
+}
@@ -0,0 +1,96 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package channel
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/chanxuehong/wechat/mp"
+	"github.com/chanxuehong/wechat/mp/account"
+	"github.com/chanxuehong/wechat/mp/message/request"
+)
+
+type Channel struct {
+	clt   *account.Client
+	store Store
+}
+
+func New(clt *account.Client, store Store) *Channel {
+	if clt == nil {
+		panic("nil account.Client")
+	}
+	if store == nil {
+		panic("nil Store")
+	}
+
+	return &Channel{
+		clt:   clt,
+		store: store,
+	}
+}
+
+func (ch *Channel) sceneId(name string) (sceneId uint32, err error) {
+	if name == "" {
+		err = errors.New("empty channel name")
+		return
+	}
+	return ch.store.Allocate(name)
+}
+
+// 创建渠道 name 的永久二维码.
+func (ch *Channel) PermanentQRCode(name string) (qrcode *account.PermanentQRCode, err error) {
+	sceneId, err := ch.sceneId(name)
+	if err != nil {
+		return
+	}
+	return ch.clt.CreatePermanentQRCode(sceneId)
+}
+
+// 创建渠道 name 的临时二维码.
+func (ch *Channel) TemporaryQRCode(name string, expireSeconds int) (qrcode *account.TemporaryQRCode, err error) {
+	sceneId, err := ch.sceneId(name)
+	if err != nil {
+		return
+	}
+	return ch.clt.CreateTemporaryQRCode(sceneId, expireSeconds)
+}
+
+// 从扫描带参数二维码的事件中获取渠道名称.
+//  支持扫码关注事件(subscribe)和已关注用户的扫码事件(SCAN);
+//  如果 msg 不是扫码事件或者场景值没有对应的渠道, 返回 ok == false.
+func (ch *Channel) EventChannel(msg *mp.MixedMessage) (name string, ok bool, err error) {
+	if msg.MsgType != "event" {
+		return
+	}
+
+	var scene string
+	switch msg.Event {
+	case request.EventTypeSubscribe:
+		if msg.EventKey == "" { // 普通关注
+			return
+		}
+		if scene, err = request.GetSubscribeByScanEvent(msg).Scene(); err != nil {
+			return
+		}
+	case request.EventTypeScan:
+		scene = msg.EventKey
+	default:
+		return
+	}
+
+	sceneId, err := strconv.ParseUint(scene, 10, 32)
+	if err != nil {
+		err = fmt.Errorf("invalid scene_id: %q", scene)
+		return
+	}
+	if name, err = ch.store.Name(uint32(sceneId)); err != nil {
+		return
+	}
+	ok = name != ""
+	return
+}
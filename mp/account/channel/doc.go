@@ -0,0 +1,9 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+// 渠道二维码, 基于带参数二维码的渠道推广统计.
+//  每个渠道名称对应一个场景值(scene_id), 映射关系保存在 Store 里;
+//  用户扫描渠道二维码后, 通过 Channel.EventChannel 从扫码事件中得到渠道名称.
+package channel
@@ -0,0 +1,77 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package channel
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/chanxuehong/wechat/mp/account"
+)
+
+// 渠道名称和场景值映射关系的存储接口.
+//  多进程环境下需要自己实现一个共享的 Store, 比如基于数据库.
+type Store interface {
+	// 根据渠道名称获取场景值, 不存在则返回 0, nil.
+	SceneId(name string) (sceneId uint32, err error)
+
+	// 根据场景值获取渠道名称, 不存在则返回 "", nil.
+	Name(sceneId uint32) (name string, err error)
+
+	// 为渠道名称分配一个没有使用过的场景值并保存映射关系, 如果 name 已经存在则返回已经分配的场景值.
+	//  永久二维码的场景值不能超过 account.PermanentQRCodeSceneIdLimit.
+	Allocate(name string) (sceneId uint32, err error)
+}
+
+var _ Store = (*MemoryStore)(nil)
+
+// Store 的内存实现, 用于单进程环境, 重启后映射关系会丢失.
+type MemoryStore struct {
+	mutex       sync.RWMutex
+	sceneIds    map[string]uint32
+	names       map[uint32]string
+	lastSceneId uint32
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		sceneIds: make(map[string]uint32),
+		names:    make(map[uint32]string),
+	}
+}
+
+func (store *MemoryStore) SceneId(name string) (sceneId uint32, err error) {
+	store.mutex.RLock()
+	sceneId = store.sceneIds[name]
+	store.mutex.RUnlock()
+	return
+}
+
+func (store *MemoryStore) Name(sceneId uint32) (name string, err error) {
+	store.mutex.RLock()
+	name = store.names[sceneId]
+	store.mutex.RUnlock()
+	return
+}
+
+func (store *MemoryStore) Allocate(name string) (sceneId uint32, err error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	if sceneId = store.sceneIds[name]; sceneId != 0 {
+		return
+	}
+	if store.lastSceneId >= account.PermanentQRCodeSceneIdLimit {
+		err = errors.New("no more scene_id can be allocated")
+		return
+	}
+
+	store.lastSceneId++
+	sceneId = store.lastSceneId
+	store.sceneIds[name] = sceneId
+	store.names[sceneId] = name
+	return
+}
@@ -20,7 +20,7 @@ const (
 type PermanentQRCode struct {
 	// 下面两个字段同时只有一个有效, 非zero值表示有效.
 	SceneId     uint32 `json:"scene_id,omitempty"`  // 场景值ID, 临时二维码时为32位非0整型, 永久二维码时最大值为100000(目前参数只支持1--100000)
-	SceneString string `json:"scene_str,omitempty"` // 场景值ID(字符串形式的ID), 字符串类型, 长度限制为1到64, 仅永久二维码支持此字段
+	SceneString string `json:"scene_str,omitempty"` // 场景值ID(字符串形式的ID), 字符串类型, 长度限制为1到64
 
 	Ticket string `json:"ticket"` // 获取的二维码ticket, 凭借此ticket可以在有效时间内换取二维码.
 	URL    string `json:"url"`    // 二维码图片解析后的地址, 开发者可根据该地址自行生成需要的二维码图片
@@ -113,6 +113,50 @@ func (clt *Client) CreatePermanentQRCode(SceneId uint32) (qrcode *PermanentQRCod
 	return
 }
 
+// 创建临时二维码
+//  SceneString:   场景值ID(字符串形式的ID), 字符串类型, 长度限制为1到64
+//  ExpireSeconds: 二维码有效时间, 以秒为单位.  最大不超过 604800.
+func (clt *Client) CreateTemporaryQRCodeWithSceneString(SceneString string, ExpireSeconds int) (qrcode *TemporaryQRCode, err error) {
+	if SceneString == "" {
+		err = errors.New("SceneString should not be empty")
+		return
+	}
+	if ExpireSeconds <= 0 {
+		err = errors.New("ExpireSeconds should be greater than 0")
+		return
+	}
+	var request struct {
+		ExpireSeconds int    `json:"expire_seconds"`
+		ActionName    string `json:"action_name"`
+		ActionInfo    struct {
+			Scene struct {
+				SceneString string `json:"scene_str"`
+			} `json:"scene"`
+		} `json:"action_info"`
+	}
+	request.ExpireSeconds = ExpireSeconds
+	request.ActionName = "QR_STR_SCENE"
+	request.ActionInfo.Scene.SceneString = SceneString
+
+	var result struct {
+		mp.Error
+		TemporaryQRCode
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/qrcode/create?access_token="
+	if err = ((*mp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	result.TemporaryQRCode.SceneString = SceneString
+	qrcode = &result.TemporaryQRCode
+	return
+}
+
 // 创建永久二维码
 //  SceneString: 场景值ID(字符串形式的ID), 字符串类型, 长度限制为1到64
 func (clt *Client) CreatePermanentQRCodeWithSceneString(SceneString string) (qrcode *PermanentQRCode, err error) {
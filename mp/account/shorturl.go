@@ -6,11 +6,19 @@
 package account
 
 import (
+	"errors"
+
 	"github.com/chanxuehong/wechat/mp"
 )
 
 // 将一条长链接转成短链接.
+//  longURL: 需要转换的长链接, 支持 http://, https://, weixin://wxpay 格式的 url
 func (clt *Client) ShortURL(longURL string) (shortURL string, err error) {
+	if longURL == "" {
+		err = errors.New("empty longURL")
+		return
+	}
+
 	var request = struct {
 		Action  string `json:"action"`
 		LongURL string `json:"long_url"`
@@ -33,6 +41,10 @@ func (clt *Client) ShortURL(longURL string) (shortURL string, err error) {
 		err = &result.Error
 		return
 	}
+	if result.ShortURL == "" {
+		err = errors.New("empty short_url in response")
+		return
+	}
 	shortURL = result.ShortURL
 	return
 }
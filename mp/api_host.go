@@ -0,0 +1,162 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 微信 api 的默认域名
+const DefaultAPIHost = "api.weixin.qq.com"
+
+// 微信 api 的备用域名, DefaultAPIHost 出现问题的时候可以使用
+const (
+	APIHost2        = "api2.weixin.qq.com"   // 通用备用域名
+	APIHostShanghai = "sh.api.weixin.qq.com" // 上海
+	APIHostShenzhen = "sz.api.weixin.qq.com" // 深圳
+	APIHostHongKong = "hk.api.weixin.qq.com" // 香港
+)
+
+const apiURLPrefix = "https://" + DefaultAPIHost + "/"
+
+// 把 incompleteURL 的域名替换为当前使用的域名(Client.HostFailover 或 Client.APIHost).
+//  incompleteURL 的域名不是 DefaultAPIHost 的时候不替换, 比如 file.api.weixin.qq.com.
+//  NOTE: 一般不用调用这个方法, PostJSON, GetJSON 等方法已经调用了.
+func (clt *Client) APIURL(incompleteURL string) string {
+	return replaceAPIHost(incompleteURL, clt.currentAPIHost())
+}
+
+func (clt *Client) currentAPIHost() string {
+	if clt.HostFailover != nil {
+		return clt.HostFailover.Host()
+	}
+	return clt.APIHost
+}
+
+func replaceAPIHost(incompleteURL, host string) string {
+	if host == "" || host == DefaultAPIHost {
+		return incompleteURL
+	}
+	if !strings.HasPrefix(incompleteURL, apiURLPrefix) {
+		return incompleteURL
+	}
+	return "https://" + host + "/" + incompleteURL[len(apiURLPrefix):]
+}
+
+// 请求 host 失败后调用, err 是 http 请求返回的错误, statusCode 是 http 响应的状态码.
+//  返回 true 表示可以换一个域名重新请求.
+//  NOTE: 只有连接没有建立成功的时候才重新请求, 否则请求可能已经被微信服务器处理了, 重新请求会导致重复调用.
+func (clt *Client) apiHostFailed(incompleteURL, host string, err error, statusCode int, failoverCount *int) bool {
+	failover := clt.HostFailover
+	if failover == nil || !strings.HasPrefix(incompleteURL, apiURLPrefix) {
+		return false
+	}
+	if err == nil && statusCode < 500 {
+		return false
+	}
+	failover.MarkFailed(host)
+
+	if err == nil || !isDialError(err) || *failoverCount >= len(failover.hosts)-1 {
+		return false
+	}
+	*failoverCount++
+	LogInfoln("[WECHAT_FAILOVER] host:", host, ", err:", err)
+	return true
+}
+
+// 在 mp 包外面自己发送 http 请求(比如下载多媒体)的时候, 请求失败后调用, 用于域名的故障转移.
+//  finalURL 是通过 APIURL 得到的 URL(可以带上 access_token 等参数), err 是 http 请求返回的错误, statusCode 是 http 响应的状态码;
+//  failoverCount 记录这个请求已经换了几次域名, 每个请求从 0 开始.
+//  返回 true 表示可以重新调用 APIURL 换一个域名重新请求.
+func (clt *Client) APIHostFailed(incompleteURL, finalURL string, err error, statusCode int, failoverCount *int) bool {
+	u, parseErr := url.Parse(finalURL)
+	if parseErr != nil {
+		return false
+	}
+	return clt.apiHostFailed(incompleteURL, u.Host, err, statusCode, failoverCount)
+}
+
+func isDialError(err error) bool {
+	if urlErr, ok := err.(*url.Error); ok {
+		err = urlErr.Err
+	}
+	opErr, ok := err.(*net.OpError)
+	return ok && opErr.Op == "dial"
+}
+
+// api 域名的故障转移.
+//  按顺序使用第一个可用的域名, 请求某个域名失败(网络错误或者 http 5xx)后这个域名在 Cooldown 时间内被标记为不可用;
+//  如果所有的域名都不可用, 则使用最早恢复的那个域名.
+type HostFailover struct {
+	hosts    []string
+	Cooldown time.Duration // 域名失败后不可用的时间, 默认 1 分钟
+
+	mutex     sync.Mutex
+	downUntil []time.Time // 和 hosts 一一对应, 在这个时间之前对应的域名不可用
+}
+
+// 创建一个新的 HostFailover.
+//  如果 hosts 为空则默认为 DefaultAPIHost, APIHost2, APIHostShanghai, APIHostShenzhen.
+func NewHostFailover(hosts ...string) *HostFailover {
+	if len(hosts) == 0 {
+		hosts = []string{DefaultAPIHost, APIHost2, APIHostShanghai, APIHostShenzhen}
+	}
+	return &HostFailover{
+		hosts:     append([]string(nil), hosts...),
+		Cooldown:  time.Minute,
+		downUntil: make([]time.Time, len(hosts)),
+	}
+}
+
+// 获取当前应该使用的域名.
+func (failover *HostFailover) Host() string {
+	now := time.Now()
+
+	failover.mutex.Lock()
+	defer failover.mutex.Unlock()
+
+	earliest := 0
+	for i := range failover.hosts {
+		if !now.Before(failover.downUntil[i]) {
+			return failover.hosts[i]
+		}
+		if failover.downUntil[i].Before(failover.downUntil[earliest]) {
+			earliest = i
+		}
+	}
+	return failover.hosts[earliest]
+}
+
+// 标记域名不可用.
+func (failover *HostFailover) MarkFailed(host string) {
+	failover.mutex.Lock()
+	defer failover.mutex.Unlock()
+
+	for i := range failover.hosts {
+		if failover.hosts[i] == host {
+			failover.downUntil[i] = time.Now().Add(failover.Cooldown)
+			return
+		}
+	}
+}
+
+// 获取所有域名的健康状态, 返回 map[域名]是否可用.
+func (failover *HostFailover) Health() map[string]bool {
+	now := time.Now()
+
+	failover.mutex.Lock()
+	defer failover.mutex.Unlock()
+
+	health := make(map[string]bool, len(failover.hosts))
+	for i, host := range failover.hosts {
+		health[host] = !now.Before(failover.downUntil[i])
+	}
+	return health
+}
@@ -0,0 +1,43 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package bar
+
+import (
+	"github.com/chanxuehong/wechat/mp"
+)
+
+const (
+	BarTypeWelcomeMP     = 0 // 显示"欢迎光临+公众号名称"
+	BarTypeWelcomeShop   = 1 // 显示"欢迎光临+门店名称"
+	BarTypeConnectedMP   = 2 // 显示"已连接+公众号名称+WiFi"
+	BarTypeConnectedShop = 3 // 显示"已连接+门店名称+Wi-Fi"
+)
+
+// 设置微信首页欢迎语.
+//  设置微信首页欢迎语, 可选择"欢迎光临XXX"或"已连接XXXWiFi", XXX代表公众号名称或门店名称.
+//  barType: BarTypeWelcomeMP, BarTypeWelcomeShop, BarTypeConnectedMP, BarTypeConnectedShop
+func Set(clt *mp.Client, shopId int64, barType int) (err error) {
+	request := struct {
+		ShopId  int64 `json:"shop_id"`
+		BarType int   `json:"bar_type"`
+	}{
+		ShopId:  shopId,
+		BarType: barType,
+	}
+
+	var result mp.Error
+
+	incompleteURL := "https://api.weixin.qq.com/bizwifi/bar/set?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
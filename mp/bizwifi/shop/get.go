@@ -0,0 +1,50 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package shop
+
+import (
+	"github.com/chanxuehong/wechat/mp"
+)
+
+type ShopInfo struct {
+	ShopName     string   `json:"shop_name"`     // 门店名称
+	SSID         string   `json:"ssid"`          // 无线网络设备的ssid，未添加设备为空，多个ssid时显示第一个
+	SSIDList     []string `json:"ssid_list"`     // 无线网络设备的ssid列表，返回数组格式
+	Password     string   `json:"password"`      // 密码，仅当门店内设备的设备类型为密码型设备时返回
+	ProtocolType int      `json:"protocol_type"` // 门店内设备的设备类型，0-未添加设备，4-密码型设备，31-portal型设备
+	APCount      int      `json:"ap_count"`      // 门店内设备总数
+	TemplateId   int      `json:"template_id"`   // 商家主页模板类型
+	HomepageURL  string   `json:"homepage_url"`  // 商家主页链接
+	BarType      int      `json:"bar_type"`      // 顶部常驻入口上显示的文本内容
+	PoiId        string   `json:"poi_id"`        // 与微信门店ID对应的门店ID
+	Sid          string   `json:"sid"`           // 商户自己的ID
+}
+
+// 查询门店Wi-Fi信息.
+func Get(clt *mp.Client, shopId int64) (info *ShopInfo, err error) {
+	request := struct {
+		ShopId int64 `json:"shop_id"`
+	}{
+		ShopId: shopId,
+	}
+
+	var result struct {
+		mp.Error
+		ShopInfo `json:"data"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/bizwifi/shop/get?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	info = &result.ShopInfo
+	return
+}
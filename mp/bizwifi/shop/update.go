@@ -0,0 +1,39 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package shop
+
+import (
+	"errors"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+type UpdateParameters struct {
+	ShopId   int64  `json:"shop_id"`            // 必须, 门店ID
+	OldSSID  string `json:"old_ssid"`           // 必须, 需要修改的ssid，当门店下有多个ssid时，必填
+	SSID     string `json:"ssid"`               // 必须, 无线网络设备的ssid，32个字符以内；ssid支持中文，但可能因设备兼容性问题导致显示乱码，或无法连接等问题，相关风险自行承担！当门店下是portal型设备时，ssid必填；当门店下是密码型设备时，ssid选填，且ssid和密码必须有一个以大写字母"WX"开头
+	Password string `json:"password,omitempty"` // 可选, 无线网络设备的密码，8-24个字符；不能包含中文字符；当门店下是密码型设备时，才可填写password，且ssid和密码必须有一个以大写字母"WX"开头
+}
+
+// 修改门店网络信息.
+func Update(clt *mp.Client, para *UpdateParameters) (err error) {
+	if para == nil {
+		return errors.New("nil UpdateParameters")
+	}
+
+	var result mp.Error
+
+	incompleteURL := "https://api.weixin.qq.com/bizwifi/shop/update?access_token="
+	if err = clt.PostJSON(incompleteURL, para, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
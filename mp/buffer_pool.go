@@ -10,14 +10,49 @@ import (
 	"sync"
 )
 
-var textBufferPool = sync.Pool{
-	New: func() interface{} {
-		return bytes.NewBuffer(make([]byte, 0, 16<<10)) // 16KB
-	},
+// 按容量分级的 bytes.Buffer 池.
+//  绝大部分 JSON 请求都很小, 只用一个大容量的池会让每个 buffer 都占用大量内存;
+//  分级之后小请求用小 buffer, 偶尔的大请求也不会让大 buffer 常驻小请求的池.
+var bufferPoolSizes = [...]int{
+	4 << 10,  // 4KB
+	64 << 10, // 64KB
+	1 << 20,  // 1MB
 }
 
-var mediaBufferPool = sync.Pool{
-	New: func() interface{} {
-		return bytes.NewBuffer(make([]byte, 0, 10<<20)) // 10MB
-	},
+var bufferPools [len(bufferPoolSizes)]sync.Pool
+
+func init() {
+	for i := range bufferPools {
+		size := bufferPoolSizes[i]
+		bufferPools[i].New = func() interface{} {
+			return bytes.NewBuffer(make([]byte, 0, size))
+		}
+	}
+}
+
+// 获取一个容量至少为 sizeHint 的 buffer(已经 Reset), 用完后调用 putBuffer 放回.
+//  sizeHint <= 0 表示未知, 返回最小一级的 buffer.
+func getBuffer(sizeHint int) *bytes.Buffer {
+	for i, size := range bufferPoolSizes {
+		if sizeHint <= size {
+			buf := bufferPools[i].Get().(*bytes.Buffer)
+			buf.Reset()
+			return buf
+		}
+	}
+	return bytes.NewBuffer(make([]byte, 0, sizeHint))
+}
+
+// 把 buffer 放回容量对应的池, 超过最大一级两倍容量的 buffer 直接丢弃, 防止常驻内存.
+func putBuffer(buf *bytes.Buffer) {
+	n := buf.Cap()
+	if n > 2*bufferPoolSizes[len(bufferPoolSizes)-1] {
+		return
+	}
+	for i := len(bufferPoolSizes) - 1; i >= 0; i-- {
+		if n >= bufferPoolSizes[i] {
+			bufferPools[i].Put(buf)
+			return
+		}
+	}
 }
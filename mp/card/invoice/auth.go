@@ -0,0 +1,154 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package invoice
+
+import (
+	"errors"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+const (
+	AuthTypeInvoice = 0 // 开票授权
+	AuthTypeRefill  = 1 // 填写字段开票授权
+	AuthTypeTitle   = 2 // 领票授权
+)
+
+type GetAuthURLParameters struct {
+	SPAppId     string `json:"s_pappid"`               // 必须, 开票平台在微信的标识号, 商户需要找开票平台提供
+	OrderId     string `json:"order_id"`               // 必须, 订单id, 在商户内单笔开票请求的唯一识别号
+	Money       int    `json:"money"`                  // 必须, 订单金额, 以分为单位
+	Timestamp   int64  `json:"timestamp"`              // 必须, 时间戳
+	Source      string `json:"source"`                 // 必须, 开票来源, app: app开票, web: 微信h5开票, wxa: 小程序开发票, wap: 普通网页开票
+	RedirectURL string `json:"redirect_url,omitempty"` // 可选, 授权成功后跳转页面. 本字段只有在source为H5的时候需要填写
+	Ticket      string `json:"ticket"`                 // 必须, 授权页 ticket, 即 type=wx_card 的 api_ticket
+	Type        int    `json:"type"`                   // 必须, 授权类型, AuthTypeInvoice, AuthTypeRefill, AuthTypeTitle
+}
+
+// 获取授权页链接.
+//  商户在调用授权页前需要先获取一个7天有效的授权页 ticket, 即 type=wx_card 的 api_ticket.
+func GetAuthURL(clt *mp.Client, para *GetAuthURLParameters) (authURL, appId string, err error) {
+	if para == nil {
+		err = errors.New("nil GetAuthURLParameters")
+		return
+	}
+
+	var result struct {
+		mp.Error
+		AuthURL string `json:"auth_url"`
+		AppId   string `json:"appid"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/card/invoice/getauthurl?access_token="
+	if err = clt.PostJSON(incompleteURL, para, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	authURL = result.AuthURL
+	appId = result.AppId
+	return
+}
+
+const (
+	InvoiceStatusAuthSuccess = "auth success" // 已授权
+	InvoiceStatusAuthFail    = "auth fail"    // 授权失败
+)
+
+type CustomField struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+type AuthData struct {
+	InvoiceStatus string `json:"invoice_status"` // 订单授权状态, InvoiceStatusAuthSuccess, InvoiceStatusAuthFail
+	AuthTime      int64  `json:"auth_time"`      // 授权时间, 为十位时间戳(utc+8)
+	UserAuthInfo  struct {
+		UserField *UserField `json:"user_field,omitempty"` // 个人抬头
+		BizField  *BizField  `json:"biz_field,omitempty"`  // 单位抬头
+	} `json:"user_auth_info"` // 用户授权信息
+}
+
+// 个人抬头
+type UserField struct {
+	Title        string        `json:"title"`        // 个人抬头
+	Phone        string        `json:"phone"`        // 手机号
+	Email        string        `json:"email"`        // 邮箱地址
+	CustomFields []CustomField `json:"custom_field"` // 自定义字段
+}
+
+// 单位抬头
+type BizField struct {
+	Title        string        `json:"title"`        // 单位抬头
+	TaxNo        string        `json:"tax_no"`       // 单位税号
+	Addr         string        `json:"addr"`         // 单位地址
+	Phone        string        `json:"phone"`        // 单位电话
+	BankType     string        `json:"bank_type"`    // 开户银行
+	BankNo       string        `json:"bank_no"`      // 银行账号
+	CustomFields []CustomField `json:"custom_field"` // 自定义字段
+}
+
+// 查询授权完成状态.
+//  sPAppId: 开票平台在微信的标识号
+func GetAuthData(clt *mp.Client, orderId, sPAppId string) (data *AuthData, err error) {
+	request := struct {
+		OrderId string `json:"order_id"`
+		SPAppId string `json:"s_pappid"`
+	}{
+		OrderId: orderId,
+		SPAppId: sPAppId,
+	}
+
+	var result struct {
+		mp.Error
+		AuthData
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/card/invoice/getauthdata?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	data = &result.AuthData
+	return
+}
+
+// 拒绝开票.
+//  reason: 拒绝开票的原因
+//  url:    跳转链接, 引导用户进行下一步处理, 可以为空
+func RejectInsert(clt *mp.Client, sPAppId, orderId, reason, url string) (err error) {
+	request := struct {
+		SPAppId string `json:"s_pappid"`
+		OrderId string `json:"order_id"`
+		Reason  string `json:"reason"`
+		URL     string `json:"url,omitempty"`
+	}{
+		SPAppId: sPAppId,
+		OrderId: orderId,
+		Reason:  reason,
+		URL:     url,
+	}
+
+	var result mp.Error
+
+	incompleteURL := "https://api.weixin.qq.com/card/invoice/rejectinsert?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
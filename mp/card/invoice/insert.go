@@ -0,0 +1,88 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package invoice
+
+import (
+	"errors"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 发票的商品详情
+type BillingInfo struct {
+	Name  string `json:"name"`           // 项目的名称
+	Num   int    `json:"num,omitempty"`  // 项目的数量
+	Unit  string `json:"unit,omitempty"` // 项目的单位, 如个
+	Fee   int    `json:"fee"`            // 项目的金额, 以分为单位
+	Price int    `json:"price"`          // 项目的单价, 以分为单位
+}
+
+// 发票的具体内容
+type InvoiceUserData struct {
+	Fee                   int           `json:"fee"`                                // 必须, 发票的金额, 以分为单位
+	Title                 string        `json:"title"`                              // 必须, 发票的抬头
+	BillingTime           int64         `json:"billing_time"`                       // 必须, 发票的开票时间, 为10位时间戳(utc+8)
+	BillingNo             string        `json:"billing_no"`                         // 必须, 发票的发票号码
+	BillingCode           string        `json:"billing_code"`                       // 必须, 发票的发票代码
+	Info                  []BillingInfo `json:"info,omitempty"`                     // 可选, 商品信息结构
+	FeeWithoutTax         int           `json:"fee_without_tax"`                    // 必须, 不含税金额, 以分为单位
+	Tax                   int           `json:"tax"`                                // 必须, 税额, 以分为单位
+	SPdfMediaId           string        `json:"s_pdf_media_id"`                     // 必须, 发票pdf文件上传到微信发票平台后, 会生成一个发票s_media_id
+	STripPdfMediaId       string        `json:"s_trip_pdf_media_id,omitempty"`      // 可选, 其它消费附件的PDF
+	CheckCode             string        `json:"check_code"`                         // 必须, 校验码, 发票pdf右上角, 开票日期下的校验码
+	BuyerNumber           string        `json:"buyer_number,omitempty"`             // 可选, 购买方纳税人识别号
+	BuyerAddressAndPhone  string        `json:"buyer_address_and_phone,omitempty"`  // 可选, 购买方地址, 电话
+	BuyerBankAccount      string        `json:"buyer_bank_account,omitempty"`       // 可选, 购买方开户行及账号
+	SellerNumber          string        `json:"seller_number,omitempty"`            // 可选, 销售方纳税人识别号
+	SellerAddressAndPhone string        `json:"seller_address_and_phone,omitempty"` // 可选, 销售方地址, 电话
+	SellerBankAccount     string        `json:"seller_bank_account,omitempty"`      // 可选, 销售方开户行及账号
+	Remarks               string        `json:"remarks,omitempty"`                  // 可选, 备注
+	Cashier               string        `json:"cashier,omitempty"`                  // 可选, 收款人
+	Maker                 string        `json:"maker,omitempty"`                    // 可选, 开票人
+}
+
+type InsertParameters struct {
+	OrderId string `json:"order_id"` // 必须, 发票order_id, 既商户给用户授权开票的订单号
+	CardId  string `json:"card_id"`  // 必须, 发票card_id
+	AppId   string `json:"appid"`    // 必须, 该订单号授权时使用的appid, 一般为商户appid
+	CardExt struct {
+		NonceStr string `json:"nonce_str"` // 必须, 随机字符串, 防止重复
+		UserCard struct {
+			InvoiceUserData InvoiceUserData `json:"invoice_user_data"`
+		} `json:"user_card"` // 必须, 用户信息结构体
+	} `json:"card_ext"` // 必须, 发票具体内容
+}
+
+type InsertResult struct {
+	Code    string `json:"code"`    // 发票code
+	OpenId  string `json:"openid"`  // 获得发票用户的openid
+	UnionId string `json:"unionid"` // 只有在用户将公众号绑定到微信开放平台帐号后, 才会出现该字段
+}
+
+// 将电子发票卡券插入用户卡包.
+func Insert(clt *mp.Client, para *InsertParameters) (rslt *InsertResult, err error) {
+	if para == nil {
+		err = errors.New("nil InsertParameters")
+		return
+	}
+
+	var result struct {
+		mp.Error
+		InsertResult
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/card/invoice/insert?access_token="
+	if err = clt.PostJSON(incompleteURL, para, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	rslt = &result.InsertResult
+	return
+}
@@ -23,3 +23,17 @@ func Sign(strs []string) (signature string) {
 	}
 	return hex.EncodeToString(h.Sum(nil))
 }
+
+// js-sdk wx.chooseCard 的 cardSign 签名.
+//  apiTicket 为 wx_card 类型的 api_ticket, 参考 jssdk.WxCardTicketServer;
+//  locationId, cardId, cardType 可以为空.
+func ChooseCardSign(apiTicket, appId, locationId, timestamp, nonceStr, cardId, cardType string) (signature string) {
+	return Sign([]string{apiTicket, appId, locationId, timestamp, nonceStr, cardId, cardType})
+}
+
+// js-sdk wx.addCard 的 cardExt 里的 signature 签名.
+//  apiTicket 为 wx_card 类型的 api_ticket, 参考 jssdk.WxCardTicketServer;
+//  code, openId 可以为空.
+func AddCardSign(apiTicket, timestamp, cardId, code, openId, nonceStr string) (signature string) {
+	return Sign([]string{apiTicket, timestamp, cardId, code, openId, nonceStr})
+}
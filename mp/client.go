@@ -9,18 +9,23 @@ package mp
 
 import (
 	"bytes"
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
 	"reflect"
-
-	wechatjson "github.com/chanxuehong/wechat/json"
 )
 
 type Client struct {
 	AccessTokenServer
-	HttpClient *http.Client
+	HttpClient  *http.Client
+	RateLimiter RateLimiter // api 调用频率限制器, 可以为 nil, 表示不限制
+	APIHost     string      // api 的域名, 为空表示 DefaultAPIHost, 比如可以设置为 api2.weixin.qq.com
+
+	// api 域名的故障转移, 可以为 nil; 不为 nil 时 APIHost 不起作用
+	HostFailover *HostFailover
+
+	// 每次 http 请求前后调用的钩子, 可以为 nil
+	Hook Hook
 }
 
 // 创建一个新的 Client.
@@ -51,35 +56,49 @@ func NewClient(srv AccessTokenServer, clt *http.Client) *Client {
 //          ...
 //      }
 func (clt *Client) PostJSON(incompleteURL string, request interface{}, response interface{}) (err error) {
-	buf := textBufferPool.Get().(*bytes.Buffer)
-	buf.Reset()
-	defer textBufferPool.Put(buf)
-
-	if err = wechatjson.NewEncoder(buf).Encode(request); err != nil {
+	buf, err := getJSONEncodeBuffer(request)
+	if err != nil {
 		return
 	}
+	defer putJSONEncodeBuffer(buf)
 	requestBytes := buf.Bytes()
 
+	if clt.RateLimiter != nil {
+		if err = clt.RateLimiter.Take(apiName(incompleteURL)); err != nil {
+			return
+		}
+	}
+
 	token, err := clt.Token()
 	if err != nil {
 		return
 	}
 
 	hasRetried := false
+	failoverCount := 0
 RETRY:
-	finalURL := incompleteURL + url.QueryEscape(token)
+	apiHost := clt.currentAPIHost()
+	finalURL := replaceAPIHost(incompleteURL, apiHost) + url.QueryEscape(token)
+	requestInfo := clt.beforeRequest("POST", incompleteURL, finalURL, token, len(requestBytes))
 
 	httpResp, err := clt.HttpClient.Post(finalURL, "application/json; charset=utf-8", bytes.NewReader(requestBytes))
 	if err != nil {
+		clt.afterRequest(requestInfo, 0, 0, err)
+		if clt.apiHostFailed(incompleteURL, apiHost, err, 0, &failoverCount) {
+			goto RETRY
+		}
 		return
 	}
 	defer httpResp.Body.Close()
 
 	if httpResp.StatusCode != http.StatusOK {
+		clt.apiHostFailed(incompleteURL, apiHost, nil, httpResp.StatusCode, &failoverCount)
+		clt.afterRequest(requestInfo, httpResp.StatusCode, 0, nil)
 		return fmt.Errorf("http.Status: %s", httpResp.Status)
 	}
 
-	if err = json.NewDecoder(httpResp.Body).Decode(response); err != nil {
+	if err = decodeJSON(httpResp.Body, response); err != nil {
+		clt.afterRequest(requestInfo, httpResp.StatusCode, 0, err)
 		return
 	}
 
@@ -92,11 +111,19 @@ RETRY:
 	} else {
 		ErrorStructValue = responseStructValue
 	}
+	setErrorAPI(ErrorStructValue, incompleteURL)
+
+	clt.afterRequest(requestInfo, httpResp.StatusCode, int(ErrorStructValue.Field(0).Int()), nil)
 
 	switch ErrCode := ErrorStructValue.Field(0).Int(); ErrCode {
 	case ErrCodeOK:
 		return
-	case ErrCodeInvalidCredential, ErrCodeAccessTokenExpired:
+	case ErrCodeAPIQuotaExceeded:
+		if clt.RateLimiter != nil {
+			clt.RateLimiter.Exhausted(apiName(incompleteURL))
+		}
+		return
+	case ErrCodeInvalidCredential, ErrCodeInvalidAccessToken, ErrCodeAccessTokenExpired:
 		ErrMsg := ErrorStructValue.Field(1).String()
 		LogInfoln("[WECHAT_RETRY] err_code:", ErrCode, ", err_msg:", ErrMsg)
 		LogInfoln("[WECHAT_RETRY] current token:", token)
@@ -130,26 +157,42 @@ RETRY:
 //          ...
 //      }
 func (clt *Client) GetJSON(incompleteURL string, response interface{}) (err error) {
+	if clt.RateLimiter != nil {
+		if err = clt.RateLimiter.Take(apiName(incompleteURL)); err != nil {
+			return
+		}
+	}
+
 	token, err := clt.Token()
 	if err != nil {
 		return
 	}
 
 	hasRetried := false
+	failoverCount := 0
 RETRY:
-	finalURL := incompleteURL + url.QueryEscape(token)
+	apiHost := clt.currentAPIHost()
+	finalURL := replaceAPIHost(incompleteURL, apiHost) + url.QueryEscape(token)
+	requestInfo := clt.beforeRequest("GET", incompleteURL, finalURL, token, 0)
 
 	httpResp, err := clt.HttpClient.Get(finalURL)
 	if err != nil {
+		clt.afterRequest(requestInfo, 0, 0, err)
+		if clt.apiHostFailed(incompleteURL, apiHost, err, 0, &failoverCount) {
+			goto RETRY
+		}
 		return
 	}
 	defer httpResp.Body.Close()
 
 	if httpResp.StatusCode != http.StatusOK {
+		clt.apiHostFailed(incompleteURL, apiHost, nil, httpResp.StatusCode, &failoverCount)
+		clt.afterRequest(requestInfo, httpResp.StatusCode, 0, nil)
 		return fmt.Errorf("http.Status: %s", httpResp.Status)
 	}
 
-	if err = json.NewDecoder(httpResp.Body).Decode(response); err != nil {
+	if err = decodeJSON(httpResp.Body, response); err != nil {
+		clt.afterRequest(requestInfo, httpResp.StatusCode, 0, err)
 		return
 	}
 
@@ -162,11 +205,19 @@ RETRY:
 	} else {
 		ErrorStructValue = responseStructValue
 	}
+	setErrorAPI(ErrorStructValue, incompleteURL)
+
+	clt.afterRequest(requestInfo, httpResp.StatusCode, int(ErrorStructValue.Field(0).Int()), nil)
 
 	switch ErrCode := ErrorStructValue.Field(0).Int(); ErrCode {
 	case ErrCodeOK:
 		return
-	case ErrCodeInvalidCredential, ErrCodeAccessTokenExpired:
+	case ErrCodeAPIQuotaExceeded:
+		if clt.RateLimiter != nil {
+			clt.RateLimiter.Exhausted(apiName(incompleteURL))
+		}
+		return
+	case ErrCodeInvalidCredential, ErrCodeInvalidAccessToken, ErrCodeAccessTokenExpired:
 		ErrMsg := ErrorStructValue.Field(1).String()
 		LogInfoln("[WECHAT_RETRY] err_code:", ErrCode, ", err_msg:", ErrMsg)
 		LogInfoln("[WECHAT_RETRY] current token:", token)
@@ -0,0 +1,73 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type benchAccessTokenServer struct{}
+
+func (benchAccessTokenServer) Token() (string, error)               { return "ACCESS_TOKEN", nil }
+func (benchAccessTokenServer) TokenRefresh() (string, error)        { return "ACCESS_TOKEN", nil }
+func (benchAccessTokenServer) TagCE90001AFE9C11E48611A4DB30FED8E1() {}
+
+type benchCustomText struct {
+	ToUser  string `json:"touser"`
+	MsgType string `json:"msgtype"`
+	Text    struct {
+		Content string `json:"content"`
+	} `json:"text"`
+}
+
+func newBenchClient() (clt *Client, incompleteURL string, closeFn func()) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"errcode":0,"errmsg":"ok"}`))
+	}))
+	clt = NewClient(benchAccessTokenServer{}, srv.Client())
+	clt.APIHost = strings.TrimPrefix(srv.URL, "https://")
+	return clt, "https://api.weixin.qq.com/cgi-bin/message/custom/send?access_token=", srv.Close
+}
+
+func BenchmarkPostJSON(b *testing.B) {
+	clt, incompleteURL, closeFn := newBenchClient()
+	defer closeFn()
+
+	var msg benchCustomText
+	msg.ToUser = "OPENID"
+	msg.MsgType = "text"
+	msg.Text.Content = strings.Repeat("Hello World", 20)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var result Error
+		if err := clt.PostJSON(incompleteURL, &msg, &result); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkPostJSONErrorOnly(b *testing.B) {
+	clt, incompleteURL, closeFn := newBenchClient()
+	defer closeFn()
+
+	var msg benchCustomText
+	msg.ToUser = "OPENID"
+	msg.MsgType = "text"
+	msg.Text.Content = strings.Repeat("Hello World", 20)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := clt.PostJSONErrorOnly(incompleteURL, &msg); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"errors"
+)
+
+// 公众号调用接口并不是无限制的, 当接口调用次数超过每天的限制时, 可以调用该接口将所有 api 的调用次数清零.
+//  每个帐号每月共10次清零操作机会, 清零生效一次即用掉一次机会;
+//  微信服务器不返回剩余的清零次数, 次数用完后返回的错误可以用 IsClearQuotaLimitReached 判断.
+//  清零成功后如果 RateLimiter 有 ResetAll 方法(比如 DefaultRateLimiter)则调用该方法.
+func (clt *Client) ClearQuota(appId string) (err error) {
+	if appId == "" {
+		return errors.New("empty appId")
+	}
+
+	var request = struct {
+		AppId string `json:"appid"`
+	}{
+		AppId: appId,
+	}
+
+	var result Error
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/clear_quota?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != ErrCodeOK {
+		err = &result
+		return
+	}
+
+	if limiter, ok := clt.RateLimiter.(interface {
+		ResetAll()
+	}); ok {
+		limiter.ResetAll()
+	}
+	return
+}
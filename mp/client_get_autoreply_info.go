@@ -25,6 +25,28 @@ func (clt *Client) GetAutoReplyInfo() (info *AutoReplyInfo, err error) {
 	return
 }
 
+// 自动回复的类型
+const (
+	AutoReplyTypeText  = "text"  // 文本
+	AutoReplyTypeImage = "img"   // 图片
+	AutoReplyTypeVoice = "voice" // 语音
+	AutoReplyTypeVideo = "video" // 视频
+	AutoReplyTypeNews  = "news"  // 图文消息, 只有关键词自动回复才有
+)
+
+// 关键词自动回复的回复模式
+const (
+	ReplyModeReplyAll  = "reply_all"  // 全部回复
+	ReplyModeRandomOne = "random_one" // 随机回复其中一条
+)
+
+// 关键词的匹配模式
+const (
+	MatchModeContain = "contain" // 消息中含有该关键词即可
+	MatchModeEqual   = "equal"   // 消息内容必须和关键词严格相同
+)
+
+// 公众号在微信公众平台官网通过网站功能设置的自动回复规则, 不包括通过 api 实现的自动回复.
 type AutoReplyInfo struct {
 	IsAddFriendReplyOpen int `json:"is_add_friend_reply_open"` // 关注后自动回复是否开启，0代表未开启，1代表开启
 	IsAutoReplyOpen      int `json:"is_autoreply_open"`        // 消息自动回复是否开启，0代表未开启，1代表开启
@@ -89,3 +111,13 @@ type Article struct {
 	ContentURL string `json:"content_url"` // 正文的URL
 	SourceURL  string `json:"source_url"`  // 原文的URL，若置空则无查看原文入口
 }
+
+// 关注后自动回复是否开启
+func (info *AutoReplyInfo) AddFriendReplyOpen() bool {
+	return info.IsAddFriendReplyOpen == 1
+}
+
+// 消息自动回复是否开启
+func (info *AutoReplyInfo) AutoReplyOpen() bool {
+	return info.IsAutoReplyOpen == 1
+}
@@ -26,3 +26,24 @@ func (clt *Client) GetCallbackIP() (ipList []string, err error) {
 	ipList = result.IPList
 	return
 }
+
+// 获取微信 api 接口域名(api.weixin.qq.com)解析出来的IP地址列表.
+//  如果公众号的服务器需要限制出口访问, 可以通过该接口获得需要放行的IP地址列表.
+func (clt *Client) GetAPIDomainIP() (ipList []string, err error) {
+	var result struct {
+		Error
+		IPList []string `json:"ip_list"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/get_api_domain_ip?access_token="
+	if err = clt.GetJSON(incompleteURL, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	ipList = result.IPList
+	return
+}
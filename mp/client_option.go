@@ -0,0 +1,190 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// NewClientWithOptions 的选项
+type ClientOption func(opts *clientOptions)
+
+type clientOptions struct {
+	httpClient   *http.Client
+	proxyURL     *url.URL
+	dialTimeout  time.Duration
+	timeout      time.Duration
+	apiHost      string
+	hostFailover *HostFailover
+	rateLimiter  RateLimiter
+	hook         Hook
+
+	stableToken             bool
+	stableTokenForceRefresh bool
+
+	tokenRefreshPolicy bool
+	tokenRefreshRatio  float64
+	tokenRefreshJitter float64
+	onTokenRefreshed   TokenRefreshedFunc
+
+	tokenGetter TokenGetter
+}
+
+// 使用自定义的 http.Client, 设置了这个选项的话 WithProxy, WithTimeout 不起作用.
+func WithHttpClient(clt *http.Client) ClientOption {
+	return func(opts *clientOptions) {
+		opts.httpClient = clt
+	}
+}
+
+// 通过代理服务器访问微信服务器, 比如 http://127.0.0.1:8080
+func WithProxy(proxyURL *url.URL) ClientOption {
+	return func(opts *clientOptions) {
+		opts.proxyURL = proxyURL
+	}
+}
+
+// 设置超时时间.
+//  dialTimeout: 建立连接的超时时间, <= 0 表示不限制
+//  timeout:     整个请求(包括读取 response body)的超时时间, <= 0 表示不限制
+func WithTimeout(dialTimeout, timeout time.Duration) ClientOption {
+	return func(opts *clientOptions) {
+		opts.dialTimeout = dialTimeout
+		opts.timeout = timeout
+	}
+}
+
+// 设置 api 的域名, see Client.APIHost
+func WithAPIHost(host string) ClientOption {
+	return func(opts *clientOptions) {
+		opts.apiHost = host
+	}
+}
+
+// 设置 api 域名的故障转移, see Client.HostFailover
+func WithHostFailover(failover *HostFailover) ClientOption {
+	return func(opts *clientOptions) {
+		opts.hostFailover = failover
+	}
+}
+
+// 设置 api 调用频率限制器, see Client.RateLimiter
+func WithRateLimiter(limiter RateLimiter) ClientOption {
+	return func(opts *clientOptions) {
+		opts.rateLimiter = limiter
+	}
+}
+
+// 设置每次 http 请求前后调用的钩子, see Client.Hook
+func WithHook(hook Hook) ClientOption {
+	return func(opts *clientOptions) {
+		opts.hook = hook
+	}
+}
+
+// 使用稳定版接口(cgi-bin/stable_token)获取 access_token, see StableAccessTokenServer.
+//  forceRefresh: access_token 被微信服务器判定为无效时是否强制刷新, 一般为 false
+func WithStableToken(forceRefresh bool) ClientOption {
+	return func(opts *clientOptions) {
+		opts.stableToken = true
+		opts.stableTokenForceRefresh = forceRefresh
+	}
+}
+
+// 设置提前刷新 access_token 的策略, see DefaultAccessTokenServer.SetRefreshPolicy.
+//
+//  NOTE: 只对 DefaultAccessTokenServer 有效, 设置了 WithStableToken 的话不起作用.
+func WithTokenRefreshPolicy(ratio, jitter float64) ClientOption {
+	return func(opts *clientOptions) {
+		opts.tokenRefreshPolicy = true
+		opts.tokenRefreshRatio = ratio
+		opts.tokenRefreshJitter = jitter
+	}
+}
+
+// 设置 access_token 刷新成功后的回调函数, see DefaultAccessTokenServer.OnTokenRefreshed.
+//
+//  NOTE: 只对 DefaultAccessTokenServer 有效, 设置了 WithStableToken 的话不起作用.
+func WithOnTokenRefreshed(fn TokenRefreshedFunc) ClientOption {
+	return func(opts *clientOptions) {
+		opts.onTokenRefreshed = fn
+	}
+}
+
+// 只读模式, 通过 getter 从外部获取 access_token, Client 自己从不到微信服务器获取(刷新) access_token,
+// see ReadOnlyAccessTokenServer. 设置了这个选项的话 WithStableToken, WithTokenRefreshPolicy, WithOnTokenRefreshed 不起作用.
+func WithTokenGetter(getter TokenGetter) ClientOption {
+	return func(opts *clientOptions) {
+		opts.tokenGetter = getter
+	}
+}
+
+// 创建一个新的 Client, 内部使用 DefaultAccessTokenServer 管理 access_token,
+// 如果设置了 WithStableToken 则使用 StableAccessTokenServer, 如果设置了 WithTokenGetter 则使用 ReadOnlyAccessTokenServer.
+//
+//  NOTE:
+//  1. 整个系统只能存在一个 DefaultAccessTokenServer 实例, 所以同一个公众号只能调用一次 NewClientWithOptions,
+//     多个进程(多台机器)的环境请使用 WithStableToken 或者 NewClient + DistributedAccessTokenServer;
+//  2. WithAPIHost 只对 Client 调用的 api 起作用, AccessTokenServer 还是到 DefaultAPIHost 获取 access_token.
+func NewClientWithOptions(appId, appSecret string, opts ...ClientOption) *Client {
+	var options clientOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	httpClient := options.httpClient
+	if httpClient == nil {
+		httpClient = newHttpClient(options.proxyURL, options.dialTimeout, options.timeout)
+	}
+
+	var tokenServer AccessTokenServer
+	if options.tokenGetter != nil {
+		tokenServer = NewReadOnlyAccessTokenServer(options.tokenGetter)
+	} else if options.stableToken {
+		tokenServer = NewStableAccessTokenServer(appId, appSecret, options.stableTokenForceRefresh, httpClient)
+	} else {
+		srv := NewDefaultAccessTokenServer(appId, appSecret, httpClient)
+		if options.tokenRefreshPolicy {
+			srv.SetRefreshPolicy(options.tokenRefreshRatio, options.tokenRefreshJitter)
+		}
+		srv.OnTokenRefreshed(options.onTokenRefreshed)
+		tokenServer = srv
+	}
+
+	clt := NewClient(tokenServer, httpClient)
+	clt.APIHost = options.apiHost
+	clt.HostFailover = options.hostFailover
+	clt.RateLimiter = options.rateLimiter
+	clt.Hook = options.hook
+	return clt
+}
+
+func newHttpClient(proxyURL *url.URL, dialTimeout, timeout time.Duration) *http.Client {
+	if proxyURL == nil && dialTimeout <= 0 && timeout <= 0 {
+		return http.DefaultClient
+	}
+
+	dialer := &net.Dialer{
+		Timeout:   dialTimeout,
+		KeepAlive: 30 * time.Second,
+	}
+	transport := &http.Transport{
+		Proxy:               http.ProxyFromEnvironment,
+		Dial:                dialer.Dial,
+		TLSHandshakeTimeout: 10 * time.Second,
+	}
+	if proxyURL != nil {
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	return &http.Client{
+		Transport: transport,
+		Timeout:   timeout,
+	}
+}
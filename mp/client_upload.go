@@ -8,11 +8,9 @@
 package mp
 
 import (
-	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
-	"mime/multipart"
 	"net/http"
 	"net/url"
 	"reflect"
@@ -47,39 +45,20 @@ type MultipartFormField struct {
 //          Error
 //          ...
 //      }
+//  4. 实现了 io.Seeker 的 Value(比如 *os.File)是流式发送的, 不会把整个文件读入内存;
+//     其他的 Value 会先读入内存. access_token 过期等情况都可以重试.
 func (clt *Client) PostMultipartForm(incompleteURL string, fields []MultipartFormField, response interface{}) (err error) {
-	bodyBuf := mediaBufferPool.Get().(*bytes.Buffer)
-	bodyBuf.Reset()
-	defer mediaBufferPool.Put(bodyBuf)
-
-	multipartWriter := multipart.NewWriter(bodyBuf)
-
-	for _, field := range fields {
-		switch field.ContentType {
-		case 0: // 文件
-			partWriter, err := multipartWriter.CreateFormFile(field.FieldName, field.FileName)
-			if err != nil {
-				return err
-			}
-			if _, err = io.Copy(partWriter, field.Value); err != nil {
-				return err
-			}
-		case 1: // 文本
-			partWriter, err := multipartWriter.CreateFormField(field.FieldName)
-			if err != nil {
-				return err
-			}
-			if _, err = io.Copy(partWriter, field.Value); err != nil {
-				return err
-			}
-		}
-	}
-
-	if err = multipartWriter.Close(); err != nil {
+	body, err := newMultipartBody(fields)
+	if err != nil {
 		return
 	}
+	defer body.close()
 
-	bodyBytes := bodyBuf.Bytes()
+	if clt.RateLimiter != nil {
+		if err = clt.RateLimiter.Take(apiName(incompleteURL)); err != nil {
+			return
+		}
+	}
 
 	token, err := clt.Token()
 	if err != nil {
@@ -87,21 +66,45 @@ func (clt *Client) PostMultipartForm(incompleteURL string, fields []MultipartFor
 	}
 
 	hasRetried := false
+	failoverCount := 0
 RETRY:
-	finalURL := incompleteURL + url.QueryEscape(token)
+	apiHost := clt.currentAPIHost()
+	finalURL := replaceAPIHost(incompleteURL, apiHost) + url.QueryEscape(token)
+	requestInfo := clt.beforeRequest("POST", incompleteURL, finalURL, token, body.size())
+
+	bodyReader, err := body.open()
+	if err != nil {
+		clt.afterRequest(requestInfo, 0, 0, err)
+		return
+	}
+	httpReq, err := http.NewRequest("POST", finalURL, bodyReader)
+	if err != nil {
+		bodyReader.Close()
+		clt.afterRequest(requestInfo, 0, 0, err)
+		return
+	}
+	httpReq.Header.Set("Content-Type", body.contentType())
+	httpReq.ContentLength = body.contentLength
 
-	httpResp, err := clt.HttpClient.Post(finalURL, multipartWriter.FormDataContentType(), bytes.NewReader(bodyBytes))
+	httpResp, err := clt.HttpClient.Do(httpReq)
 	if err != nil {
+		clt.afterRequest(requestInfo, 0, 0, err)
+		if clt.apiHostFailed(incompleteURL, apiHost, err, 0, &failoverCount) {
+			goto RETRY
+		}
 		return
 	}
 	defer httpResp.Body.Close()
 
 	if httpResp.StatusCode != http.StatusOK {
+		clt.apiHostFailed(incompleteURL, apiHost, nil, httpResp.StatusCode, &failoverCount)
+		clt.afterRequest(requestInfo, httpResp.StatusCode, 0, nil)
 		err = fmt.Errorf("http.Status: %s", httpResp.Status)
 		return
 	}
 
 	if err = json.NewDecoder(httpResp.Body).Decode(response); err != nil {
+		clt.afterRequest(requestInfo, httpResp.StatusCode, 0, err)
 		return
 	}
 
@@ -114,11 +117,19 @@ RETRY:
 	} else {
 		ErrorStructValue = responseStructValue
 	}
+	setErrorAPI(ErrorStructValue, incompleteURL)
+
+	clt.afterRequest(requestInfo, httpResp.StatusCode, int(ErrorStructValue.Field(0).Int()), nil)
 
 	switch ErrCode := ErrorStructValue.Field(0).Int(); ErrCode {
 	case ErrCodeOK:
 		return
-	case ErrCodeInvalidCredential, ErrCodeAccessTokenExpired:
+	case ErrCodeAPIQuotaExceeded:
+		if clt.RateLimiter != nil {
+			clt.RateLimiter.Exhausted(apiName(incompleteURL))
+		}
+		return
+	case ErrCodeInvalidCredential, ErrCodeInvalidAccessToken, ErrCodeAccessTokenExpired:
 		ErrMsg := ErrorStructValue.Field(1).String()
 		LogInfoln("[WECHAT_RETRY] err_code:", ErrCode, ", err_msg:", ErrMsg)
 		LogInfoln("[WECHAT_RETRY] current token:", token)
@@ -0,0 +1,161 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package comment
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 图文消息的标识, 用于定位一篇文章.
+type Article struct {
+	MsgDataId int64 `json:"msg_data_id"`     // 群发返回的msg_data_id
+	Index     int   `json:"index,omitempty"` // 多图文时, 用来指定第几篇图文, 从0开始, 不带默认操作该msg_data_id的第一篇图文
+}
+
+// 打开已群发文章评论.
+func (clt *Client) Open(article Article) (err error) {
+	return clt.post("https://api.weixin.qq.com/cgi-bin/comment/open?access_token=", &article)
+}
+
+// 关闭已群发文章评论.
+func (clt *Client) Close(article Article) (err error) {
+	return clt.post("https://api.weixin.qq.com/cgi-bin/comment/close?access_token=", &article)
+}
+
+const (
+	ListTypeAll     = 0 // 普通评论&精选评论
+	ListTypeNormal  = 1 // 普通评论
+	ListTypeElected = 2 // 精选评论
+)
+
+const (
+	CommentTypeNormal  = 0 // 普通评论
+	CommentTypeElected = 1 // 精选评论
+)
+
+const ListCountLimit = 50 // List 每次获取的数目必须小于 ListCountLimit
+
+type Comment struct {
+	UserCommentId int64  `json:"user_comment_id"` // 用户评论id
+	OpenId        string `json:"openid"`          // openid
+	CreateTime    int64  `json:"create_time"`     // 评论时间
+	Content       string `json:"content"`         // 评论内容
+	CommentType   int    `json:"comment_type"`    // 是否精选评论, CommentTypeNormal, CommentTypeElected
+	Reply         *struct {
+		Content    string `json:"content"`     // 作者回复内容
+		CreateTime int64  `json:"create_time"` // 作者回复时间
+	} `json:"reply,omitempty"` // 作者回复, 没有回复时为 nil
+}
+
+type ListResult struct {
+	Total    int       `json:"total"`   // 总数, 非comment的size
+	Comments []Comment `json:"comment"` // 评论列表
+}
+
+// 查看指定文章的评论数据.
+//  begin:    起始位置
+//  count:    获取数目(>=50会被拒绝)
+//  listType: ListTypeAll, ListTypeNormal, ListTypeElected
+func (clt *Client) List(article Article, begin, count, listType int) (rslt *ListResult, err error) {
+	if begin < 0 {
+		err = fmt.Errorf("invalid begin: %d", begin)
+		return
+	}
+	if count <= 0 || count >= ListCountLimit {
+		err = fmt.Errorf("invalid count: %d, must be in (0, %d)", count, ListCountLimit)
+		return
+	}
+
+	request := struct {
+		Article
+		Begin int `json:"begin"`
+		Count int `json:"count"`
+		Type  int `json:"type"`
+	}{
+		Article: article,
+		Begin:   begin,
+		Count:   count,
+		Type:    listType,
+	}
+
+	var result struct {
+		mp.Error
+		ListResult
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/comment/list?access_token="
+	if err = ((*mp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	rslt = &result.ListResult
+	return
+}
+
+type commentRequest struct {
+	Article
+	UserCommentId int64 `json:"user_comment_id"`
+}
+
+// 将评论标记精选.
+func (clt *Client) MarkElect(article Article, userCommentId int64) (err error) {
+	return clt.post("https://api.weixin.qq.com/cgi-bin/comment/markelect?access_token=",
+		&commentRequest{Article: article, UserCommentId: userCommentId})
+}
+
+// 将评论取消精选.
+func (clt *Client) UnmarkElect(article Article, userCommentId int64) (err error) {
+	return clt.post("https://api.weixin.qq.com/cgi-bin/comment/unmarkelect?access_token=",
+		&commentRequest{Article: article, UserCommentId: userCommentId})
+}
+
+// 删除评论.
+func (clt *Client) Delete(article Article, userCommentId int64) (err error) {
+	return clt.post("https://api.weixin.qq.com/cgi-bin/comment/delete?access_token=",
+		&commentRequest{Article: article, UserCommentId: userCommentId})
+}
+
+// 回复评论.
+func (clt *Client) ReplyAdd(article Article, userCommentId int64, content string) (err error) {
+	if content == "" {
+		return errors.New("empty content")
+	}
+
+	request := struct {
+		commentRequest
+		Content string `json:"content"`
+	}{
+		commentRequest: commentRequest{Article: article, UserCommentId: userCommentId},
+		Content:        content,
+	}
+	return clt.post("https://api.weixin.qq.com/cgi-bin/comment/reply/add?access_token=", &request)
+}
+
+// 删除回复.
+func (clt *Client) ReplyDelete(article Article, userCommentId int64) (err error) {
+	return clt.post("https://api.weixin.qq.com/cgi-bin/comment/reply/delete?access_token=",
+		&commentRequest{Article: article, UserCommentId: userCommentId})
+}
+
+func (clt *Client) post(incompleteURL string, request interface{}) (err error) {
+	var result mp.Error
+	if err = ((*mp.Client)(clt)).PostJSON(incompleteURL, request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
@@ -7,6 +7,7 @@ package component
 
 import (
 	"errors"
+	"net/http"
 	"strconv"
 	"sync"
 	"time"
@@ -59,6 +60,19 @@ func NewAuthorizerAccessTokenServer(clt *Client, authorizerAppId, authorizerRefr
 
 func (srv *AuthorizerAccessTokenServer) TagCE90001AFE9C11E48611A4DB30FED8E1() {}
 
+// 获取最新的 authorizer_refresh_token, 调用者可以持久化它, 用于下次创建 AuthorizerAccessTokenServer.
+func (srv *AuthorizerAccessTokenServer) RefreshToken() string {
+	srv.tokenCache.RLock()
+	defer srv.tokenCache.RUnlock()
+	return srv.tokenCache.RefreshToken
+}
+
+// 创建一个代公众号调用接口的 mp.Client, 所有的 mp 接口都会使用授权公众号的 authorizer_access_token.
+//  如果 httpClient == nil 则默认使用 http.DefaultClient.
+func NewAuthorizerClient(clt *Client, authorizerAppId, authorizerRefreshToken string, httpClient *http.Client) *mp.Client {
+	return mp.NewClient(NewAuthorizerAccessTokenServer(clt, authorizerAppId, authorizerRefreshToken), httpClient)
+}
+
 // 獲取 authorizer_access_token
 func (srv *AuthorizerAccessTokenServer) Token() (token string, err error) {
 	srv.tokenCache.RLock()
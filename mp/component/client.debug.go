@@ -108,7 +108,7 @@ RETRY:
 	switch ErrCode := ErrorStructValue.Field(0).Int(); ErrCode {
 	case mp.ErrCodeOK:
 		return
-	case mp.ErrCodeInvalidCredential, mp.ErrCodeAccessTokenExpired:
+	case mp.ErrCodeInvalidCredential, mp.ErrCodeInvalidAccessToken, mp.ErrCodeAccessTokenExpired:
 		ErrMsg := ErrorStructValue.Field(1).String()
 		mp.LogInfoln("[WECHAT_RETRY] err_code:", ErrCode, ", err_msg:", ErrMsg)
 		mp.LogInfoln("[WECHAT_RETRY] current token:", token)
@@ -185,7 +185,7 @@ RETRY:
 	switch ErrCode := ErrorStructValue.Field(0).Int(); ErrCode {
 	case mp.ErrCodeOK:
 		return
-	case mp.ErrCodeInvalidCredential, mp.ErrCodeAccessTokenExpired:
+	case mp.ErrCodeInvalidCredential, mp.ErrCodeInvalidAccessToken, mp.ErrCodeAccessTokenExpired:
 		ErrMsg := ErrorStructValue.Field(1).String()
 		mp.LogInfoln("[WECHAT_RETRY] err_code:", ErrCode, ", err_msg:", ErrMsg)
 		mp.LogInfoln("[WECHAT_RETRY] current token:", token)
@@ -49,6 +49,9 @@ type MixedMessage struct {
 	CreateTime int64  `xml:"CreateTime" json:"CreateTime"`
 	InfoType   string `xml:"InfoType"   json:"InfoType"`
 
-	VerifyTicket    string `xml:"ComponentVerifyTicket" json:"ComponentVerifyTicket"`
-	AuthorizerAppId string `xml:"AuthorizerAppid"       json:"AuthorizerAppid"`
+	VerifyTicket                 string `xml:"ComponentVerifyTicket"        json:"ComponentVerifyTicket"`
+	AuthorizerAppId              string `xml:"AuthorizerAppid"              json:"AuthorizerAppid"`
+	AuthorizationCode            string `xml:"AuthorizationCode"            json:"AuthorizationCode"`
+	AuthorizationCodeExpiredTime int64  `xml:"AuthorizationCodeExpiredTime" json:"AuthorizationCodeExpiredTime"`
+	PreAuthCode                  string `xml:"PreAuthCode"                  json:"PreAuthCode"`
 }
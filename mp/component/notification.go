@@ -7,8 +7,10 @@ package component
 
 const (
 	// 微信服务器推送过来的消息类型
-	MsgTypeVerifyTicket = "component_verify_ticket" // 推送 component_verify_ticket 协议
-	MsgTypeUnauthorized = "unauthorized"            // 取消授权的通知
+	MsgTypeVerifyTicket     = "component_verify_ticket" // 推送 component_verify_ticket 协议
+	MsgTypeUnauthorized     = "unauthorized"            // 取消授权的通知
+	MsgTypeAuthorized       = "authorized"              // 授权成功的通知
+	MsgTypeUpdateAuthorized = "updateauthorized"        // 授权更新的通知
 )
 
 type VerifyTicketMessage struct {
@@ -48,3 +50,29 @@ func GetUnauthorizedMessage(msg *MixedMessage) *UnauthorizedMessage {
 		AuthorizerAppId: msg.AuthorizerAppId,
 	}
 }
+
+// 授权成功, 授权更新的通知
+type AuthorizedMessage struct {
+	XMLName struct{} `xml:"xml" json:"-"`
+
+	AppId      string `xml:"AppId"      json:"AppId"`
+	CreateTime int64  `xml:"CreateTime" json:"CreateTime"`
+	InfoType   string `xml:"InfoType"   json:"InfoType"`
+
+	AuthorizerAppId              string `xml:"AuthorizerAppid"              json:"AuthorizerAppid"`
+	AuthorizationCode            string `xml:"AuthorizationCode"            json:"AuthorizationCode"`            // 授权码, 可用于 Client.QueryAuth 换取公众号的授权信息
+	AuthorizationCodeExpiredTime int64  `xml:"AuthorizationCodeExpiredTime" json:"AuthorizationCodeExpiredTime"` // 授权码过期时间
+	PreAuthCode                  string `xml:"PreAuthCode"                  json:"PreAuthCode"`                  // 预授权码
+}
+
+func GetAuthorizedMessage(msg *MixedMessage) *AuthorizedMessage {
+	return &AuthorizedMessage{
+		AppId:                        msg.AppId,
+		CreateTime:                   msg.CreateTime,
+		InfoType:                     msg.InfoType,
+		AuthorizerAppId:              msg.AuthorizerAppId,
+		AuthorizationCode:            msg.AuthorizationCode,
+		AuthorizationCodeExpiredTime: msg.AuthorizationCodeExpiredTime,
+		PreAuthCode:                  msg.PreAuthCode,
+	}
+}
@@ -41,6 +41,9 @@ func (clt *Client) GetArticleSummary(req *Request) (list []ArticleSummaryData, e
 		err = errors.New("nil Request")
 		return
 	}
+	if err = req.checkSpan(1); err != nil {
+		return
+	}
 
 	var result struct {
 		mp.Error
@@ -79,6 +82,9 @@ func (clt *Client) GetArticleTotal(req *Request) (list []ArticleTotalData, err e
 		err = errors.New("nil Request")
 		return
 	}
+	if err = req.checkSpan(1); err != nil {
+		return
+	}
 
 	var result struct {
 		mp.Error
@@ -111,6 +117,9 @@ func (clt *Client) GetUserRead(req *Request) (list []UserReadData, err error) {
 		err = errors.New("nil Request")
 		return
 	}
+	if err = req.checkSpan(3); err != nil {
+		return
+	}
 
 	var result struct {
 		mp.Error
@@ -143,6 +152,9 @@ func (clt *Client) GetUserReadHour(req *Request) (list []UserReadHourData, err e
 		err = errors.New("nil Request")
 		return
 	}
+	if err = req.checkSpan(1); err != nil {
+		return
+	}
 
 	var result struct {
 		mp.Error
@@ -177,6 +189,9 @@ func (clt *Client) GetUserShare(req *Request) (list []UserShareData, err error)
 		err = errors.New("nil Request")
 		return
 	}
+	if err = req.checkSpan(7); err != nil {
+		return
+	}
 
 	var result struct {
 		mp.Error
@@ -208,6 +223,9 @@ func (clt *Client) GetUserShareHour(req *Request) (list []UserShareHourData, err
 		err = errors.New("nil Request")
 		return
 	}
+	if err = req.checkSpan(1); err != nil {
+		return
+	}
 
 	var result struct {
 		mp.Error
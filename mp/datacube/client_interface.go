@@ -26,6 +26,9 @@ func (clt *Client) GetInterfaceSummary(req *Request) (list []InterfaceSummaryDat
 		err = errors.New("nil Request")
 		return
 	}
+	if err = req.checkSpan(30); err != nil {
+		return
+	}
 
 	var result struct {
 		mp.Error
@@ -56,6 +59,9 @@ func (clt *Client) GetInterfaceSummaryHour(req *Request) (list []InterfaceSummar
 		err = errors.New("nil Request")
 		return
 	}
+	if err = req.checkSpan(1); err != nil {
+		return
+	}
 
 	var result struct {
 		mp.Error
@@ -33,6 +33,9 @@ func (clt *Client) GetUpstreamMsg(req *Request) (list []UpstreamMsgData, err err
 		err = errors.New("nil Request")
 		return
 	}
+	if err = req.checkSpan(7); err != nil {
+		return
+	}
 
 	var result struct {
 		mp.Error
@@ -64,6 +67,9 @@ func (clt *Client) GetUpstreamMsgHour(req *Request) (list []UpstreamMsgHourData,
 		err = errors.New("nil Request")
 		return
 	}
+	if err = req.checkSpan(1); err != nil {
+		return
+	}
 
 	var result struct {
 		mp.Error
@@ -92,6 +98,9 @@ func (clt *Client) GetUpstreamMsgWeek(req *Request) (list []UpstreamMsgWeekData,
 		err = errors.New("nil Request")
 		return
 	}
+	if err = req.checkSpan(30); err != nil {
+		return
+	}
 
 	var result struct {
 		mp.Error
@@ -120,6 +129,9 @@ func (clt *Client) GetUpstreamMsgMonth(req *Request) (list []UpstreamMsgMonthDat
 		err = errors.New("nil Request")
 		return
 	}
+	if err = req.checkSpan(30); err != nil {
+		return
+	}
 
 	var result struct {
 		mp.Error
@@ -153,6 +165,9 @@ func (clt *Client) GetUpstreamMsgDist(req *Request) (list []UpstreamMsgDistData,
 		err = errors.New("nil Request")
 		return
 	}
+	if err = req.checkSpan(15); err != nil {
+		return
+	}
 
 	var result struct {
 		mp.Error
@@ -181,6 +196,9 @@ func (clt *Client) GetUpstreamMsgDistWeek(req *Request) (list []UpstreamMsgDistW
 		err = errors.New("nil Request")
 		return
 	}
+	if err = req.checkSpan(30); err != nil {
+		return
+	}
 
 	var result struct {
 		mp.Error
@@ -209,6 +227,9 @@ func (clt *Client) GetUpstreamMsgDistMonth(req *Request) (list []UpstreamMsgDist
 		err = errors.New("nil Request")
 		return
 	}
+	if err = req.checkSpan(30); err != nil {
+		return
+	}
 
 	var result struct {
 		mp.Error
@@ -38,6 +38,9 @@ func (clt *Client) GetUserSummary(req *Request) (list []UserSummaryData, err err
 		err = errors.New("nil Request")
 		return
 	}
+	if err = req.checkSpan(7); err != nil {
+		return
+	}
 
 	var result struct {
 		mp.Error
@@ -70,6 +73,9 @@ func (clt *Client) GetUserCumulate(req *Request) (list []UserCumulateData, err e
 		err = errors.New("nil Request")
 		return
 	}
+	if err = req.checkSpan(7); err != nil {
+		return
+	}
 
 	var result struct {
 		mp.Error
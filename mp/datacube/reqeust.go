@@ -6,6 +6,7 @@
 package datacube
 
 import (
+	"fmt"
 	"time"
 )
 
@@ -29,3 +30,22 @@ func NewRequest(BeginDate, EndDate time.Time) *Request {
 		EndDate:   EndDate.Format("2006-01-02"),
 	}
 }
+
+// 检查 BeginDate, EndDate 的格式和跨度, maxSpan 为接口的最大时间跨度(天).
+func (req *Request) checkSpan(maxSpan int) (err error) {
+	beginDate, err := time.Parse("2006-01-02", req.BeginDate)
+	if err != nil {
+		return fmt.Errorf("invalid BeginDate: %s", req.BeginDate)
+	}
+	endDate, err := time.Parse("2006-01-02", req.EndDate)
+	if err != nil {
+		return fmt.Errorf("invalid EndDate: %s", req.EndDate)
+	}
+	if endDate.Before(beginDate) {
+		return fmt.Errorf("EndDate %s is before BeginDate %s", req.EndDate, req.BeginDate)
+	}
+	if span := int(endDate.Sub(beginDate)/(24*time.Hour)) + 1; span > maxSpan {
+		return fmt.Errorf("the span between BeginDate and EndDate is %d days, exceeds the limit %d days", span, maxSpan)
+	}
+	return
+}
@@ -0,0 +1,197 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+)
+
+var _ AccessTokenServer = (*DistributedAccessTokenServer)(nil)
+
+// AccessTokenServer 的多进程实现.
+//  NOTE:
+//  1. access_token 保存在 TokenStorage 里, 多个进程(多台机器)通过同一个 TokenStorage 共享 access_token;
+//  2. 刷新 access_token 的时候会获取 TokenStorage 的锁, 同一时刻只有一个进程到微信服务器获取 access_token;
+//  3. 每个进程可以有一个 DistributedAccessTokenServer 实例, 但是它们必须使用同一个 TokenStorage.
+type DistributedAccessTokenServer struct {
+	appId      string
+	appSecret  string
+	httpClient *http.Client
+	storage    TokenStorage
+
+	tokenGet      sync.Mutex   // 进程内同一时刻只能一个 goroutine 刷新 access_token
+	refreshFlight singleflight // 合并进程内并发的刷新请求
+
+	tokenCache struct {
+		sync.RWMutex
+		Token     string
+		ExpiresAt int64
+	}
+}
+
+// 创建一个新的 DistributedAccessTokenServer.
+//  如果 clt == nil 则默认使用 http.DefaultClient.
+func NewDistributedAccessTokenServer(appId, appSecret string, storage TokenStorage, clt *http.Client) *DistributedAccessTokenServer {
+	if storage == nil {
+		panic("nil TokenStorage")
+	}
+	if clt == nil {
+		clt = http.DefaultClient
+	}
+
+	return &DistributedAccessTokenServer{
+		appId:      appId,
+		appSecret:  appSecret,
+		httpClient: clt,
+		storage:    storage,
+	}
+}
+
+func (srv *DistributedAccessTokenServer) TagCE90001AFE9C11E48611A4DB30FED8E1() {}
+
+func (srv *DistributedAccessTokenServer) Token() (token string, err error) {
+	timeNowUnix := time.Now().Unix()
+
+	srv.tokenCache.RLock()
+	token = srv.tokenCache.Token
+	expiresAt := srv.tokenCache.ExpiresAt
+	srv.tokenCache.RUnlock()
+
+	if token != "" && timeNowUnix < expiresAt {
+		return
+	}
+
+	// 本地缓存无效, 从 TokenStorage 读取, 可能已经被别的进程刷新了
+	if token, expiresAt, err = srv.storage.Get(); err != nil {
+		return
+	}
+	if token != "" && timeNowUnix < expiresAt {
+		srv.setCache(token, expiresAt)
+		return
+	}
+	return srv.refreshOnce(token)
+}
+
+func (srv *DistributedAccessTokenServer) TokenRefresh() (token string, err error) {
+	srv.tokenCache.RLock()
+	token = srv.tokenCache.Token
+	srv.tokenCache.RUnlock()
+
+	return srv.refreshOnce(token)
+}
+
+// 合并进程内并发的刷新请求, 正在刷新的时候其他 goroutine 等待并共享刷新的结果.
+func (srv *DistributedAccessTokenServer) refreshOnce(invalidToken string) (token string, err error) {
+	value, err := srv.refreshFlight.Do(func() (interface{}, error) {
+		return srv.refresh(invalidToken)
+	})
+	if err != nil {
+		return
+	}
+	token = value.(string)
+	return
+}
+
+func (srv *DistributedAccessTokenServer) setCache(token string, expiresAt int64) {
+	srv.tokenCache.Lock()
+	srv.tokenCache.Token = token
+	srv.tokenCache.ExpiresAt = expiresAt
+	srv.tokenCache.Unlock()
+}
+
+// 刷新 access_token, invalidToken 是调用者认为已经失效的 access_token.
+//  如果 TokenStorage 里的 access_token 和 invalidToken 不一样并且没有过期, 说明别的进程(goroutine)已经刷新过了,
+//  直接返回 TokenStorage 里的 access_token, 不再到微信服务器获取.
+func (srv *DistributedAccessTokenServer) refresh(invalidToken string) (token string, err error) {
+	srv.tokenGet.Lock()
+	defer srv.tokenGet.Unlock()
+
+	unlock, err := srv.storage.Lock()
+	if err != nil {
+		return
+	}
+	defer unlock()
+
+	token, expiresAt, err := srv.storage.Get()
+	if err != nil {
+		return
+	}
+	if token != "" && token != invalidToken && time.Now().Unix() < expiresAt {
+		srv.setCache(token, expiresAt)
+		return
+	}
+
+	token, expiresAt, err = srv.getTokenFromWechat()
+	if err != nil {
+		srv.setCache("", 0)
+		return
+	}
+	if err = srv.storage.Put(token, expiresAt); err != nil {
+		return
+	}
+	srv.setCache(token, expiresAt)
+	return
+}
+
+// 从微信服务器获取 access_token, 返回 access_token 和它的过期时间(unixtime).
+func (srv *DistributedAccessTokenServer) getTokenFromWechat() (token string, expiresAt int64, err error) {
+	timeNowUnix := time.Now().Unix()
+
+	_url := "https://api.weixin.qq.com/cgi-bin/token?grant_type=client_credential&appid=" + url.QueryEscape(srv.appId) +
+		"&secret=" + url.QueryEscape(srv.appSecret)
+	httpResp, err := srv.httpClient.Get(_url)
+	if err != nil {
+		return
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		err = fmt.Errorf("http.Status: %s", httpResp.Status)
+		return
+	}
+
+	var result struct {
+		Error
+		accessTokenInfo
+	}
+	if err = json.NewDecoder(httpResp.Body).Decode(&result); err != nil {
+		return
+	}
+
+	if result.ErrCode != ErrCodeOK {
+		err = &result.Error
+		return
+	}
+
+	// 由于网络的延时, access_token 过期时间留了一个缓冲区
+	switch {
+	case result.ExpiresIn > 31556952: // 60*60*24*365.2425
+		err = errors.New("expires_in too large: " + strconv.FormatInt(result.ExpiresIn, 10))
+		return
+	case result.ExpiresIn > 60*60:
+		result.ExpiresIn -= 60 * 10
+	case result.ExpiresIn > 60*30:
+		result.ExpiresIn -= 60 * 5
+	case result.ExpiresIn > 60*5:
+		result.ExpiresIn -= 60
+	case result.ExpiresIn > 60:
+		result.ExpiresIn -= 10
+	default:
+		err = errors.New("expires_in too small: " + strconv.FormatInt(result.ExpiresIn, 10))
+		return
+	}
+
+	token = result.Token
+	expiresAt = timeNowUnix + result.ExpiresIn
+	return
+}
@@ -7,6 +7,7 @@ package record
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/chanxuehong/wechat/mp"
 )
@@ -55,3 +56,53 @@ func GetRecord(clt *mp.Client, request *GetRecordRequest) (recordList []Record,
 	recordList = result.RecordList
 	return
 }
+
+const (
+	MsgListNumberLimit = 10000
+)
+
+type GetMsgListRequest struct {
+	StartTime int64 `json:"starttime"` // 起始时间, UNIX时间戳
+	EndTime   int64 `json:"endtime"`   // 结束时间, UNIX时间戳, 每次查询时段不能超过24小时
+	MsgId     int64 `json:"msgid"`     // 消息id顺序从小到大, 从1开始
+	Number    int   `json:"number"`    // 每次获取条数, 最多10000条
+}
+
+type GetMsgListResult struct {
+	RecordList []Record `json:"recordlist"`
+	Number     int      `json:"number"` // 这次获取的记录条数, 如果等于 request.Number 则可能还有记录没有获取
+	MsgId      int64    `json:"msgid"`  // 下一次获取的 request.MsgId
+}
+
+// 获取客服聊天记录(新版).
+func GetMsgList(clt *mp.Client, request *GetMsgListRequest) (rslt *GetMsgListResult, err error) {
+	if request == nil {
+		err = errors.New("nil GetMsgListRequest")
+		return
+	}
+	if request.EndTime-request.StartTime > 24*60*60 {
+		err = errors.New("the period between StartTime and EndTime exceeds 24 hours")
+		return
+	}
+	if request.Number <= 0 || request.Number > MsgListNumberLimit {
+		err = fmt.Errorf("invalid Number: %d", request.Number)
+		return
+	}
+
+	var result struct {
+		mp.Error
+		GetMsgListResult
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/customservice/msgrecord/getmsglist?access_token="
+	if err = clt.PostJSON(incompleteURL, request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	rslt = &result.GetMsgListResult
+	return
+}
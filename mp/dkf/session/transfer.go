@@ -0,0 +1,71 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package session
+
+import (
+	"net/http"
+
+	"github.com/chanxuehong/wechat/mp"
+	"github.com/chanxuehong/wechat/mp/message/response"
+)
+
+// 把收到的消息转发到多客服的 MessageHandler.
+//  kfAccount 不为空则转发给指定的客服, 为空则由微信分配客服.
+func TransferHandler(kfAccount string) mp.MessageHandler {
+	return TransferHandlerFunc(func(*mp.Request) string { return kfAccount })
+}
+
+// 把收到的消息转发到多客服的 MessageHandler, 每条消息调用 selectKf 选择客服.
+//  selectKf 返回空串表示由微信分配客服, 比如可以用 GetSession 查询用户之前接入的客服.
+func TransferHandlerFunc(selectKf func(r *mp.Request) (kfAccount string)) mp.MessageHandler {
+	if selectKf == nil {
+		panic("nil selectKf")
+	}
+	return mp.MessageHandlerFunc(func(w http.ResponseWriter, r *mp.Request) {
+		reply := response.ReplyTransferToCustomerService(r.MixedMsg, selectKf(r))
+		if err := mp.WriteResponse(w, r, reply); err != nil {
+			mp.LogInfoln("[WECHAT_DKF] write response error:", err)
+		}
+	})
+}
+
+// 接入会话事件的处理函数, 实现了 mp.MessageHandler 接口.
+type KfCreateSessionEventHandlerFunc func(http.ResponseWriter, *mp.Request, *KfCreateSessionEvent)
+
+func (fn KfCreateSessionEventHandlerFunc) ServeMessage(w http.ResponseWriter, r *mp.Request) {
+	fn(w, r, GetKfCreateSessionEvent(r.MixedMsg))
+}
+
+// 关闭会话事件的处理函数, 实现了 mp.MessageHandler 接口.
+type KfCloseSessionEventHandlerFunc func(http.ResponseWriter, *mp.Request, *KfCloseSessionEvent)
+
+func (fn KfCloseSessionEventHandlerFunc) ServeMessage(w http.ResponseWriter, r *mp.Request) {
+	fn(w, r, GetKfCloseSessionEvent(r.MixedMsg))
+}
+
+// 转接会话事件的处理函数, 实现了 mp.MessageHandler 接口.
+type KfSwitchSessionEventHandlerFunc func(http.ResponseWriter, *mp.Request, *KfSwitchSessionEvent)
+
+func (fn KfSwitchSessionEventHandlerFunc) ServeMessage(w http.ResponseWriter, r *mp.Request) {
+	fn(w, r, GetKfSwitchSessionEvent(r.MixedMsg))
+}
+
+// 在 mux 上注册客服会话事件的处理函数, 为 nil 的处理函数不注册.
+func HandleSessionEvents(mux *mp.MessageServeMux,
+	onCreate func(http.ResponseWriter, *mp.Request, *KfCreateSessionEvent),
+	onClose func(http.ResponseWriter, *mp.Request, *KfCloseSessionEvent),
+	onSwitch func(http.ResponseWriter, *mp.Request, *KfSwitchSessionEvent)) {
+
+	if onCreate != nil {
+		mux.EventHandle(EventTypeKfCreateSession, KfCreateSessionEventHandlerFunc(onCreate))
+	}
+	if onClose != nil {
+		mux.EventHandle(EventTypeKfCloseSession, KfCloseSessionEventHandlerFunc(onClose))
+	}
+	if onSwitch != nil {
+		mux.EventHandle(EventTypeKfSwitchSession, KfSwitchSessionEventHandlerFunc(onSwitch))
+	}
+}
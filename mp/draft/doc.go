@@ -0,0 +1,7 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+// 草稿箱API, 新增的图文先保存到草稿箱, 然后通过 freepublish 发布或者通过群发接口群发.
+package draft
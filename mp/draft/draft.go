@@ -0,0 +1,234 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package draft
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 一篇草稿最多包含的文章个数
+const ArticleCountLimit = 8
+
+// 草稿(已发布图文)里的文章
+type Article struct {
+	Title              string `json:"title"`                           // 必须; 标题
+	Author             string `json:"author,omitempty"`                // 作者
+	Digest             string `json:"digest,omitempty"`                // 图文消息的摘要, 仅有单图文消息才有摘要, 多图文此处为空; 如果本字段为没有填写, 则默认抓取正文前54个字
+	Content            string `json:"content"`                         // 必须; 图文消息的具体内容, 支持HTML标签, 必须少于2万字符, 小于1M, 且此处会去除JS
+	ContentSourceURL   string `json:"content_source_url,omitempty"`    // 图文消息的原文地址, 即点击"阅读原文"后的URL
+	ThumbMediaId       string `json:"thumb_media_id"`                  // 必须; 图文消息的封面图片素材id(必须是永久MediaID)
+	NeedOpenComment    int    `json:"need_open_comment,omitempty"`     // 是否打开评论, 0不打开(默认), 1打开
+	OnlyFansCanComment int    `json:"only_fans_can_comment,omitempty"` // 是否粉丝才可评论, 0所有人可评论(默认), 1粉丝才可评论
+	PicCrop235_1       string `json:"pic_crop_235_1,omitempty"`        // 封面裁剪为2.35:1规格的坐标字段, 比如 0.1945_0_1_0.5236
+	PicCrop1_1         string `json:"pic_crop_1_1,omitempty"`          // 封面裁剪为1:1规格的坐标字段, 比如 0.166454_0_0.833545_1
+
+	// 下面的字段只在获取的时候有效
+	URL       string `json:"url,omitempty"`        // 草稿的临时链接(已发布图文的永久链接)
+	ThumbURL  string `json:"thumb_url,omitempty"`  // 图文消息的封面图片URL
+	IsDeleted bool   `json:"is_deleted,omitempty"` // 该图文是否被删除, 只在获取已发布图文时有效
+}
+
+// 新建草稿, 返回草稿的 media_id.
+func (clt *Client) Add(articles []Article) (mediaId string, err error) {
+	if len(articles) == 0 {
+		err = errors.New("草稿是空的")
+		return
+	}
+	if len(articles) > ArticleCountLimit {
+		err = fmt.Errorf("草稿的文章个数不能超过 %d, 现在为 %d", ArticleCountLimit, len(articles))
+		return
+	}
+
+	var request = struct {
+		Articles []Article `json:"articles"`
+	}{
+		Articles: articles,
+	}
+
+	var result struct {
+		mp.Error
+		MediaId string `json:"media_id"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/draft/add?access_token="
+	if err = ((*mp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	mediaId = result.MediaId
+	return
+}
+
+// 获取草稿.
+func (clt *Client) Get(mediaId string) (articles []Article, err error) {
+	if mediaId == "" {
+		err = errors.New("empty mediaId")
+		return
+	}
+
+	var request = struct {
+		MediaId string `json:"media_id"`
+	}{
+		MediaId: mediaId,
+	}
+
+	var result struct {
+		mp.Error
+		Articles []Article `json:"news_item"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/draft/get?access_token="
+	if err = ((*mp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	articles = result.Articles
+	return
+}
+
+// 删除草稿.
+func (clt *Client) Delete(mediaId string) (err error) {
+	if mediaId == "" {
+		return errors.New("empty mediaId")
+	}
+
+	var request = struct {
+		MediaId string `json:"media_id"`
+	}{
+		MediaId: mediaId,
+	}
+
+	var result mp.Error
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/draft/delete?access_token="
+	if err = ((*mp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
+
+// 修改草稿.
+//  index: 要更新的文章在草稿中的位置(多图文消息时, 此字段才有意义), 第一篇为0
+func (clt *Client) Update(mediaId string, index int, article *Article) (err error) {
+	if mediaId == "" {
+		return errors.New("empty mediaId")
+	}
+	if index < 0 || index >= ArticleCountLimit {
+		return fmt.Errorf("Incorrect index: %d", index)
+	}
+	if article == nil {
+		return errors.New("nil article")
+	}
+
+	var request = struct {
+		MediaId string   `json:"media_id"`
+		Index   int      `json:"index"`
+		Article *Article `json:"articles"`
+	}{
+		MediaId: mediaId,
+		Index:   index,
+		Article: article,
+	}
+
+	var result mp.Error
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/draft/update?access_token="
+	if err = ((*mp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
+
+// 获取草稿的总数.
+func (clt *Client) Count() (total int, err error) {
+	var result struct {
+		mp.Error
+		TotalCount int `json:"total_count"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/draft/count?access_token="
+	if err = ((*mp.Client)(clt)).GetJSON(incompleteURL, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	total = result.TotalCount
+	return
+}
+
+type DraftInfo struct {
+	MediaId string `json:"media_id"` // 草稿的 media_id
+	Content struct {
+		Articles []Article `json:"news_item,omitempty"`
+	} `json:"content"`
+	UpdateTime int64 `json:"update_time"` // 最后更新时间
+}
+
+type BatchGetResult struct {
+	TotalCount int         `json:"total_count"` // 草稿的总数
+	ItemCount  int         `json:"item_count"`  // 本次调用获取的草稿的数量
+	Items      []DraftInfo `json:"item"`        // 本次调用获取的草稿列表
+}
+
+// 获取草稿列表.
+//
+//  offset:    从全部草稿的该偏移位置开始返回, 0表示从第一个草稿返回
+//  count:     返回草稿的数量, 取值在1到20之间
+//  noContent: 为 true 时不返回文章的 content 字段
+func (clt *Client) BatchGet(offset, count int, noContent bool) (rslt *BatchGetResult, err error) {
+	var request = struct {
+		Offset    int `json:"offset"`
+		Count     int `json:"count"`
+		NoContent int `json:"no_content"`
+	}{
+		Offset: offset,
+		Count:  count,
+	}
+	if noContent {
+		request.NoContent = 1
+	}
+
+	var result struct {
+		mp.Error
+		BatchGetResult
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/draft/batchget?access_token="
+	if err = ((*mp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	rslt = &result.BatchGetResult
+	return
+}
@@ -5,20 +5,105 @@
 
 package mp
 
-import "fmt"
+import (
+	"fmt"
+	"reflect"
+)
 
+// 全局返回码, 详见微信文档
 const (
-	ErrCodeOK                 = 0
-	ErrCodeInvalidCredential  = 40001 // access_token 过期(无效)返回这个错误
-	ErrCodeAccessTokenExpired = 42001 // access_token 过期(无效)返回这个错误(maybe!!!)
+	ErrCodeSystemBusy          = -1 // 系统繁忙, 此时请开发者稍候再试
+	ErrCodeOK                  = 0
+	ErrCodeInvalidCredential   = 40001 // access_token 过期(无效)返回这个错误
+	ErrCodeInvalidGrantType    = 40002 // 不合法的凭证类型
+	ErrCodeInvalidOpenId       = 40003 // 不合法的 OpenID
+	ErrCodeInvalidMediaType    = 40004 // 不合法的媒体文件类型
+	ErrCodeInvalidMediaId      = 40007 // 不合法的媒体文件id
+	ErrCodeInvalidMessageType  = 40008 // 不合法的消息类型
+	ErrCodeInvalidAppId        = 40013 // 不合法的 AppID
+	ErrCodeInvalidAccessToken  = 40014 // 不合法的 access_token, 比如 access_token 已经被刷新(maybe!!!)
+	ErrCodeInvalidOAuthCode    = 40029 // 不合法的 oauth_code
+	ErrCodeInvalidAppSecret    = 40125 // 不合法的 AppSecret
+	ErrCodeInvalidIP           = 40164 // 调用接口的 IP 地址不在白名单中
+	ErrCodeMissingAccessToken  = 41001 // 缺少 access_token 参数
+	ErrCodeAccessTokenExpired  = 42001 // access_token 过期(无效)返回这个错误(maybe!!!)
+	ErrCodeRefreshTokenExpired = 42002 // refresh_token 超时
+	ErrCodeOAuthCodeExpired    = 42003 // oauth_code 超时
+	ErrCodeRequireGET          = 43001 // 需要 GET 请求
+	ErrCodeRequirePOST         = 43002 // 需要 POST 请求
+	ErrCodeRequireHTTPS        = 43003 // 需要 HTTPS 请求
+	ErrCodeRequireSubscribe    = 43004 // 需要接收者关注
+	ErrCodeEmptyPostData       = 44002 // POST 的数据包为空
+	ErrCodeAPIQuotaExceeded    = 45009 // 接口调用超过每天的限制
+	ErrCodeAPIFrequencyLimit   = 45011 // API 调用太频繁, 请稍候再试
+	ErrCodeOutOfResponseTime   = 45015 // 回复时间超过限制
+	ErrCodeAPIUnauthorized     = 48001 // api 功能未授权, 请确认公众号已获得该接口
+	ErrCodeAPIBlocked          = 48004 // api 接口被封禁
+	ErrCodeClearQuotaLimit     = 48006 // 清零调用次数的次数已经用完(每月10次)
+	ErrCodeUserUnauthorized    = 50001 // 用户未授权该 api
 )
 
 type Error struct {
 	// NOTE: StructField 固定这个顺序, RETRY 依赖这个顺序
 	ErrCode int    `json:"errcode"`
 	ErrMsg  string `json:"errmsg"`
+
+	API string `json:"-"` // 出错的 api 名称, 比如 /cgi-bin/menu/create, 由 Client 设置, 用于调试
 }
 
 func (e *Error) Error() string {
+	if e.API != "" {
+		return fmt.Sprintf("errcode: %d, errmsg: %s, api: %s", e.ErrCode, e.ErrMsg, e.API)
+	}
 	return fmt.Sprintf("errcode: %d, errmsg: %s", e.ErrCode, e.ErrMsg)
 }
+
+var errorType = reflect.TypeOf(Error{})
+
+// 如果 v 是 Error 则设置 Error.API
+func setErrorAPI(v reflect.Value, incompleteURL string) {
+	if v.Type() == errorType {
+		v.Field(2).SetString(apiName(incompleteURL))
+	}
+}
+
+// 获取 err 的错误码, 如果 err 不是 *Error 则 ok == false.
+func ErrCodeOf(err error) (errCode int, ok bool) {
+	if e, isError := err.(*Error); isError && e != nil {
+		return e.ErrCode, true
+	}
+	return
+}
+
+// 判断 err 是否是 access_token 过期(无效)的错误.
+func IsAccessTokenExpired(err error) bool {
+	switch errCode, _ := ErrCodeOf(err); errCode {
+	case ErrCodeInvalidCredential, ErrCodeInvalidAccessToken, ErrCodeAccessTokenExpired:
+		return true
+	}
+	return false
+}
+
+// 判断 err 是否是接口调用超过限制的错误, 包括 RateLimiter 返回的 *RateLimitError.
+func IsRateLimited(err error) bool {
+	if _, ok := err.(*RateLimitError); ok {
+		return true
+	}
+	switch errCode, _ := ErrCodeOf(err); errCode {
+	case ErrCodeAPIQuotaExceeded, ErrCodeAPIFrequencyLimit:
+		return true
+	}
+	return false
+}
+
+// 判断 err 是否是系统繁忙的错误, 这种情况可以稍候重试.
+func IsSystemBusy(err error) bool {
+	errCode, ok := ErrCodeOf(err)
+	return ok && errCode == ErrCodeSystemBusy
+}
+
+// 判断 err 是否是清零调用次数的次数已经用完的错误, 见 Client.ClearQuota.
+func IsClearQuotaLimitReached(err error) bool {
+	errCode, ok := ErrCodeOf(err)
+	return ok && errCode == ErrCodeClearQuotaLimit
+}
@@ -0,0 +1,185 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package freepublish
+
+import (
+	"errors"
+
+	"github.com/chanxuehong/wechat/mp"
+	"github.com/chanxuehong/wechat/mp/draft"
+)
+
+// 发布状态
+const (
+	PublishStatusSuccess         = 0 // 成功
+	PublishStatusPublishing      = 1 // 发布中
+	PublishStatusOriginalFailed  = 2 // 原创失败
+	PublishStatusFailed          = 3 // 常规失败
+	PublishStatusAuditFailed     = 4 // 平台审核不通过
+	PublishStatusUserDeleted     = 5 // 成功后用户删除所有文章
+	PublishStatusSystemForbidden = 6 // 成功后系统封禁所有文章
+)
+
+type SubmitResult struct {
+	PublishId string `json:"publish_id"`  // 发布任务的id
+	MsgDataId int64  `json:"msg_data_id"` // 消息的数据ID
+}
+
+// 发布草稿箱里的草稿.
+//  发布是异步的, 发布结果通过 Get 查询, 或者通过 PUBLISHJOBFINISH 事件推送.
+func (clt *Client) Submit(mediaId string) (rslt *SubmitResult, err error) {
+	if mediaId == "" {
+		err = errors.New("empty mediaId")
+		return
+	}
+
+	var request = struct {
+		MediaId string `json:"media_id"`
+	}{
+		MediaId: mediaId,
+	}
+
+	var result struct {
+		mp.Error
+		SubmitResult
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/freepublish/submit?access_token="
+	if err = ((*mp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	rslt = &result.SubmitResult
+	return
+}
+
+type PublishStatus struct {
+	PublishId     string `json:"publish_id"`     // 发布任务的id
+	PublishStatus int    `json:"publish_status"` // 发布状态, 见 PublishStatusXXX
+	ArticleId     string `json:"article_id"`     // 当发布状态为0时(即成功)时, 返回图文的 article_id, 可用于"客服消息"场景
+	ArticleDetail struct {
+		Count int `json:"count"` // 文章数量
+		Items []struct {
+			Index      int    `json:"idx"`         // 文章对应的编号
+			ArticleURL string `json:"article_url"` // 图文的永久链接
+		} `json:"item"`
+	} `json:"article_detail"` // 当发布状态为0时(即成功)时, 返回文章详细信息
+	FailIndex []int `json:"fail_idx"` // 当发布状态为2或4时, 返回不通过的文章编号, 第一篇为 1
+}
+
+// 查询发布状态.
+func (clt *Client) Get(publishId string) (status *PublishStatus, err error) {
+	if publishId == "" {
+		err = errors.New("empty publishId")
+		return
+	}
+
+	var request = struct {
+		PublishId string `json:"publish_id"`
+	}{
+		PublishId: publishId,
+	}
+
+	var result struct {
+		mp.Error
+		PublishStatus
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/freepublish/get?access_token="
+	if err = ((*mp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	status = &result.PublishStatus
+	return
+}
+
+// 删除发布.
+//  index: 要删除的文章在图文消息中的位置, 第一篇编号为1, 0 表示删除全部文章
+func (clt *Client) Delete(articleId string, index int) (err error) {
+	if articleId == "" {
+		return errors.New("empty articleId")
+	}
+
+	var request = struct {
+		ArticleId string `json:"article_id"`
+		Index     int    `json:"index,omitempty"`
+	}{
+		ArticleId: articleId,
+		Index:     index,
+	}
+
+	var result mp.Error
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/freepublish/delete?access_token="
+	if err = ((*mp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
+
+type PublishedInfo struct {
+	ArticleId string `json:"article_id"` // 成功发布的图文消息id
+	Content   struct {
+		Articles []draft.Article `json:"news_item,omitempty"`
+	} `json:"content"`
+	UpdateTime int64 `json:"update_time"` // 最后更新时间
+}
+
+type BatchGetResult struct {
+	TotalCount int             `json:"total_count"` // 成功发布的图文消息的总数
+	ItemCount  int             `json:"item_count"`  // 本次调用获取的图文消息的数量
+	Items      []PublishedInfo `json:"item"`        // 本次调用获取的图文消息列表
+}
+
+// 获取成功发布的图文消息列表.
+//
+//  offset:    从全部图文消息的该偏移位置开始返回, 0表示从第一个返回
+//  count:     返回的数量, 取值在1到20之间
+//  noContent: 为 true 时不返回文章的 content 字段
+func (clt *Client) BatchGet(offset, count int, noContent bool) (rslt *BatchGetResult, err error) {
+	var request = struct {
+		Offset    int `json:"offset"`
+		Count     int `json:"count"`
+		NoContent int `json:"no_content"`
+	}{
+		Offset: offset,
+		Count:  count,
+	}
+	if noContent {
+		request.NoContent = 1
+	}
+
+	var result struct {
+		mp.Error
+		BatchGetResult
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/freepublish/batchget?access_token="
+	if err = ((*mp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	rslt = &result.BatchGetResult
+	return
+}
@@ -16,7 +16,7 @@ import (
 )
 
 // 明文模式下回复消息给微信服务器.
-//  要求 msg 是有效的消息数据结构(经过 encoding/xml marshal 后符合微信消息格式);
+//  要求 msg 是有效的消息数据结构(经过 encoding/xml marshal 后符合微信消息格式), 字符串用 CDATA 包裹, 见 MarshalCDATAXML;
 //  如果有必要可以修改 Request 里面的某些值, 比如 Timestamp, Nonce, Random.
 func WriteRawResponse(w http.ResponseWriter, r *Request, msg interface{}) (err error) {
 	if w == nil {
@@ -25,7 +25,26 @@ func WriteRawResponse(w http.ResponseWriter, r *Request, msg interface{}) (err e
 	if msg == nil {
 		return errors.New("nil message")
 	}
-	return xml.NewEncoder(w).Encode(msg)
+
+	rawMsgXML, err := MarshalCDATAXML(msg)
+	if err != nil {
+		return
+	}
+	_, err = w.Write(rawMsgXML)
+	return
+}
+
+// 回复消息给微信服务器, 根据 r.EncryptType 自动选择明文模式或者安全模式.
+//  要求 msg 是有效的消息数据结构(经过 encoding/xml marshal 后符合微信消息格式);
+//  如果有必要可以修改 Request 里面的某些值, 比如 Timestamp, Nonce, Random.
+func WriteResponse(w http.ResponseWriter, r *Request, msg interface{}) (err error) {
+	if r == nil {
+		return errors.New("nil Request")
+	}
+	if r.EncryptType == "aes" {
+		return WriteAESResponse(w, r, msg)
+	}
+	return WriteRawResponse(w, r, msg)
 }
 
 // 安全模式下回复消息的 http body
@@ -39,7 +58,7 @@ type ResponseHttpBody struct {
 }
 
 // 安全模式下回复消息给微信服务器.
-//  要求 msg 是有效的消息数据结构(经过 encoding/xml marshal 后符合微信消息格式);
+//  要求 msg 是有效的消息数据结构(经过 encoding/xml marshal 后符合微信消息格式), 字符串用 CDATA 包裹, 见 MarshalCDATAXML;
 //  如果有必要可以修改 Request 里面的某些值, 比如 Timestamp, Nonce, Random.
 func WriteAESResponse(w http.ResponseWriter, r *Request, msg interface{}) (err error) {
 	if w == nil {
@@ -52,7 +71,7 @@ func WriteAESResponse(w http.ResponseWriter, r *Request, msg interface{}) (err e
 		return errors.New("nil message")
 	}
 
-	rawMsgXML, err := xml.Marshal(msg)
+	rawMsgXML, err := MarshalCDATAXML(msg)
 	if err != nil {
 		return
 	}
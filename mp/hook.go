@@ -0,0 +1,71 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"net/url"
+	"time"
+)
+
+// 每次 http 请求前后调用的钩子, 可以用来记录日志, 审计等.
+//  NOTE: Hook 的实现必须是并发安全的.
+type Hook interface {
+	// 发送 http 请求之前调用.
+	BeforeRequest(info *RequestInfo)
+
+	// 收到微信服务器的响应(或者请求失败)之后调用, 这个时候 info 的所有字段都已经设置.
+	AfterRequest(info *RequestInfo)
+}
+
+// 一次 http 请求的信息, 同一次请求 BeforeRequest 和 AfterRequest 的参数是同一个 *RequestInfo.
+type RequestInfo struct {
+	Method          string        // GET, POST
+	API             string        // api 名称, 比如 /cgi-bin/menu/create
+	URL             string        // 请求的 URL, access_token 已经被隐藏
+	RequestBodySize int           // 请求 body 的大小, 单位字节
+	StartTime       time.Time     // 请求开始的时间
+	Latency         time.Duration // 请求耗时, AfterRequest 时有效
+	StatusCode      int           // http 响应的状态码, 请求失败时为 0, AfterRequest 时有效
+	ErrCode         int           // 微信服务器返回的错误码, AfterRequest 时有效
+	Err             error         // 网络错误或者解析 response 的错误, AfterRequest 时有效
+}
+
+// 隐藏的 access_token 显示为这个字符串
+const redactedToken = "ACCESS_TOKEN"
+
+func (clt *Client) beforeRequest(method, incompleteURL, finalURL, token string, bodySize int) *RequestInfo {
+	if clt.Hook == nil {
+		return nil
+	}
+
+	escapedToken := url.QueryEscape(token)
+	redactedURL := finalURL
+	if n := len(finalURL) - len(escapedToken); n >= 0 && finalURL[n:] == escapedToken {
+		redactedURL = finalURL[:n] + redactedToken
+	}
+
+	info := &RequestInfo{
+		Method:          method,
+		API:             apiName(incompleteURL),
+		URL:             redactedURL,
+		RequestBodySize: bodySize,
+		StartTime:       time.Now(),
+	}
+	clt.Hook.BeforeRequest(info)
+	return info
+}
+
+func (clt *Client) afterRequest(info *RequestInfo, statusCode, errCode int, err error) {
+	if info == nil {
+		return
+	}
+
+	info.Latency = time.Since(info.StartTime)
+	info.StatusCode = statusCode
+	info.ErrCode = errCode
+	info.Err = err
+	clt.Hook.AfterRequest(info)
+}
@@ -0,0 +1,8 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+// 图像处理API: 二维码/条码识别, 图片高清化, 图片智能裁剪.
+//  要处理的图片用 ocr.ImageURL 或 ocr.ImageFile 创建.
+package img
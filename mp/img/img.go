@@ -0,0 +1,94 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package img
+
+import (
+	"github.com/chanxuehong/wechat/mp"
+	"github.com/chanxuehong/wechat/mp/ocr"
+)
+
+// 识别出的一个二维码(条码)
+type QRCodeResult struct {
+	TypeName string       `json:"type_name"` // 码的类型, 比如 QR_CODE, EAN_13, CODE_128
+	Data     string       `json:"data"`      // 码的内容
+	Position ocr.Position `json:"pos"`       // 码的位置
+}
+
+// 二维码/条码识别, 一张图片里可以识别出多个码.
+func (clt *Client) QRCode(img ocr.Image) (results []QRCodeResult, size *ocr.ImageSize, err error) {
+	var result struct {
+		mp.Error
+		CodeResults []QRCodeResult `json:"code_results"`
+		ImageSize   ocr.ImageSize  `json:"img_size"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cv/img/qrcode?"
+	if err = clt.postImage(incompleteURL, img, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	results = result.CodeResults
+	size = &result.ImageSize
+	return
+}
+
+// 图片高清化, 返回高清化后的图片的 media_id(临时素材), 可以通过 media.Client.DownloadMedia 下载.
+func (clt *Client) SuperResolution(img ocr.Image) (mediaId string, err error) {
+	var result struct {
+		mp.Error
+		MediaId string `json:"media_id"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cv/img/superresolution?"
+	if err = clt.postImage(incompleteURL, img, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	mediaId = result.MediaId
+	return
+}
+
+// 智能裁剪的一个结果
+type CropResult struct {
+	CropLeft   int `json:"crop_left"`
+	CropTop    int `json:"crop_top"`
+	CropRight  int `json:"crop_right"`
+	CropBottom int `json:"crop_bottom"`
+}
+
+// 图片智能裁剪, 返回若干个裁剪区域(按推荐程度排序).
+func (clt *Client) AICrop(img ocr.Image) (results []CropResult, size *ocr.ImageSize, err error) {
+	var result struct {
+		mp.Error
+		Results   []CropResult  `json:"results"`
+		ImageSize ocr.ImageSize `json:"img_size"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cv/img/aicrop?"
+	if err = clt.postImage(incompleteURL, img, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	results = result.Results
+	size = &result.ImageSize
+	return
+}
+
+func (clt *Client) postImage(incompleteURL string, img ocr.Image, response interface{}) error {
+	return (*ocr.Client)(clt).PostImage(incompleteURL, img, response)
+}
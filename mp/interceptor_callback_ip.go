@@ -0,0 +1,113 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+var _ Interceptor = (*CallbackIPInterceptor)(nil)
+
+// 只允许来自微信服务器IP地址的 POST 请求(推送消息), 其他 POST 请求回复 403.
+//  IP 列表通过 GetCallbackIP 获取, 微信服务器的IP地址会变化, 请定期调用 Refresh 或者 Update 更新;
+//  GET 请求(验证回调 URL)不受限制.
+type CallbackIPInterceptor struct {
+	// 获取请求的客户端IP, 为 nil 时使用 http.Request.RemoteAddr;
+	// 服务部署在反向代理后面时需要设置, 比如从 X-Real-IP 头部获取.
+	RealIP func(r *http.Request) string
+
+	rwmutex sync.RWMutex
+	ips     map[string]bool
+	nets    []*net.IPNet
+}
+
+// 创建一个新的 CallbackIPInterceptor, ipList 的元素可以是IP地址或者 CIDR, 比如 101.226.103.0/25.
+func NewCallbackIPInterceptor(ipList []string) *CallbackIPInterceptor {
+	interceptor := &CallbackIPInterceptor{}
+	interceptor.Update(ipList)
+	return interceptor
+}
+
+// 更新允许的IP地址列表.
+func (interceptor *CallbackIPInterceptor) Update(ipList []string) {
+	ips := make(map[string]bool, len(ipList))
+	var nets []*net.IPNet
+	for _, str := range ipList {
+		str = strings.TrimSpace(str)
+		if strings.Contains(str, "/") {
+			if _, ipNet, err := net.ParseCIDR(str); err == nil {
+				nets = append(nets, ipNet)
+			}
+			continue
+		}
+		if ip := net.ParseIP(str); ip != nil {
+			ips[ip.String()] = true
+		}
+	}
+
+	interceptor.rwmutex.Lock()
+	interceptor.ips = ips
+	interceptor.nets = nets
+	interceptor.rwmutex.Unlock()
+}
+
+// 调用 GetCallbackIP 获取最新的微信服务器IP地址列表并更新.
+func (interceptor *CallbackIPInterceptor) Refresh(clt *Client) (err error) {
+	ipList, err := clt.GetCallbackIP()
+	if err != nil {
+		return
+	}
+	interceptor.Update(ipList)
+	return
+}
+
+// 判断 ip 是否为微信服务器的IP地址.
+func (interceptor *CallbackIPInterceptor) Allowed(ip string) bool {
+	parsedIP := net.ParseIP(ip)
+	if parsedIP == nil {
+		return false
+	}
+
+	interceptor.rwmutex.RLock()
+	defer interceptor.rwmutex.RUnlock()
+
+	if interceptor.ips[parsedIP.String()] {
+		return true
+	}
+	for _, ipNet := range interceptor.nets {
+		if ipNet.Contains(parsedIP) {
+			return true
+		}
+	}
+	return false
+}
+
+func (interceptor *CallbackIPInterceptor) Intercept(w http.ResponseWriter, r *http.Request, queryValues url.Values) (shouldContinue bool) {
+	if r.Method != "POST" {
+		return true
+	}
+
+	var ip string
+	if interceptor.RealIP != nil {
+		ip = interceptor.RealIP(r)
+	} else {
+		ip = r.RemoteAddr
+		if host, _, err := net.SplitHostPort(ip); err == nil {
+			ip = host
+		}
+	}
+
+	if !interceptor.Allowed(ip) {
+		LogInfoln("[WECHAT_CALLBACK_IP] reject request from:", ip)
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,116 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// 返回 data(JSON object) 里 v 没有对应字段的那些 key 和值, 没有则返回 nil.
+//  微信经常给接口的响应增加字段, 有 Extra 字段的结构体(比如 merchant/order.Order)用它保存这些不认识的字段,
+//  即使 sdk 没有及时更新也可以通过 Extra 拿到.
+//  v 是结构体或者结构体的指针, 字段名按照 encoding/json 的规则匹配(json tag, 不区分大小写, 展开匿名字段).
+//
+//  一般在结构体的 UnmarshalJSON 里调用, 比如:
+//      func (order *Order) UnmarshalJSON(data []byte) (err error) {
+//          type order_ Order // 没有 UnmarshalJSON 方法, 防止递归
+//          if err = json.Unmarshal(data, (*order_)(order)); err != nil {
+//              return
+//          }
+//          order.Extra, err = mp.UnknownJSONFields(data, order)
+//          return
+//      }
+func UnknownJSONFields(data []byte, v interface{}) (extra map[string]json.RawMessage, err error) {
+	var fields map[string]json.RawMessage
+	if err = json.Unmarshal(data, &fields); err != nil {
+		return
+	}
+
+	known := jsonFieldNames(reflect.TypeOf(v))
+	for name, value := range fields {
+		if _, ok := known[name]; ok {
+			continue
+		}
+		if _, ok := known[strings.ToLower(name)]; ok {
+			continue
+		}
+		if extra == nil {
+			extra = make(map[string]json.RawMessage)
+		}
+		extra[name] = value
+	}
+	return
+}
+
+var jsonFieldNamesCache struct {
+	sync.RWMutex
+	m map[reflect.Type]map[string]struct{}
+}
+
+// 结构体的 JSON 字段名集合, 同时包含原始名称和小写名称.
+func jsonFieldNames(t reflect.Type) map[string]struct{} {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	jsonFieldNamesCache.RLock()
+	names, ok := jsonFieldNamesCache.m[t]
+	jsonFieldNamesCache.RUnlock()
+	if ok {
+		return names
+	}
+
+	names = make(map[string]struct{})
+	collectJSONFieldNames(t, names)
+
+	jsonFieldNamesCache.Lock()
+	if jsonFieldNamesCache.m == nil {
+		jsonFieldNamesCache.m = make(map[reflect.Type]map[string]struct{})
+	}
+	jsonFieldNamesCache.m[t] = names
+	jsonFieldNamesCache.Unlock()
+	return names
+}
+
+func collectJSONFieldNames(t reflect.Type, names map[string]struct{}) {
+	if t.Kind() != reflect.Struct {
+		return
+	}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" && !field.Anonymous { // 未导出的字段
+			continue
+		}
+
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := tag
+		if index := strings.IndexByte(tag, ','); index >= 0 {
+			name = tag[:index]
+		}
+
+		if name == "" && field.Anonymous {
+			fieldType := field.Type
+			if fieldType.Kind() == reflect.Ptr {
+				fieldType = fieldType.Elem()
+			}
+			if fieldType.Kind() == reflect.Struct {
+				collectJSONFieldNames(fieldType, names)
+				continue
+			}
+		}
+		if name == "" {
+			name = field.Name
+		}
+		names[name] = struct{}{}
+		names[strings.ToLower(name)] = struct{}{}
+	}
+}
@@ -0,0 +1,84 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"sync"
+
+	wechatjson "github.com/chanxuehong/wechat/json"
+)
+
+// 绑定了 buffer 的 json.Encoder, Encoder 和 buffer 一起复用.
+//  Encoder 写 bytes.Buffer 不会出错, 所以 Encoder 不会进入 sticky error 状态, 可以一直复用.
+type jsonEncodeBuffer struct {
+	bytes.Buffer
+	encoder *wechatjson.Encoder
+}
+
+var jsonEncodeBufferPool = sync.Pool{
+	New: func() interface{} {
+		buf := new(jsonEncodeBuffer)
+		buf.Grow(bufferPoolSizes[0])
+		buf.encoder = wechatjson.NewEncoder(&buf.Buffer)
+		return buf
+	},
+}
+
+// 把 v 编码为 JSON 写入一个复用的 buffer, 用完后调用 putJSONEncodeBuffer 放回.
+func getJSONEncodeBuffer(v interface{}) (buf *jsonEncodeBuffer, err error) {
+	buf = jsonEncodeBufferPool.Get().(*jsonEncodeBuffer)
+	buf.Reset()
+	if err = buf.encoder.Encode(v); err != nil {
+		putJSONEncodeBuffer(buf)
+		return nil, err
+	}
+	return
+}
+
+func putJSONEncodeBuffer(buf *jsonEncodeBuffer) {
+	if buf.Cap() > bufferPoolSizes[len(bufferPoolSizes)-1] {
+		return // 偶尔的大请求不要常驻内存
+	}
+	jsonEncodeBufferPool.Put(buf)
+}
+
+// 把 r 的内容读入复用的 buffer 后再 json.Unmarshal, 比 json.NewDecoder(r).Decode 少分配内存.
+func decodeJSON(r io.Reader, v interface{}) (err error) {
+	buf := getBuffer(0)
+	defer putBuffer(buf)
+
+	if _, err = buf.ReadFrom(r); err != nil {
+		return
+	}
+	return json.Unmarshal(buf.Bytes(), v)
+}
+
+var errorPool = sync.Pool{
+	New: func() interface{} {
+		return new(Error)
+	},
+}
+
+// 用于响应只有 errcode 和 errmsg 的接口(比如发送客服消息), 高频调用时可以减少内存分配.
+//  内部复用 Error 结构体, 只有在微信服务器返回错误时才分配新的 *Error 作为 err 返回.
+//  最终的 URL == incompleteURL + access_token.
+func (clt *Client) PostJSONErrorOnly(incompleteURL string, request interface{}) (err error) {
+	result := errorPool.Get().(*Error)
+	*result = Error{}
+	defer errorPool.Put(result)
+
+	if err = clt.PostJSON(incompleteURL, request, result); err != nil {
+		return
+	}
+	if result.ErrCode != ErrCodeOK {
+		e := *result
+		return &e
+	}
+	return
+}
@@ -8,10 +8,16 @@ package jssdk
 import (
 	"crypto/sha1"
 	"encoding/hex"
+	"strings"
 )
 
 // 微信 js-sdk wx.config 的参数签名.
+//  url: 当前网页的URL, 如果包含 #及其后面部分, 会自动去掉
 func WXConfigSign(jsapiTicket, nonceStr, timestamp, url string) (signature string) {
+	if i := strings.IndexByte(url, '#'); i >= 0 {
+		url = url[:i]
+	}
+
 	n := len("jsapi_ticket=") + len(jsapiTicket) +
 		len("&noncestr=") + len(nonceStr) +
 		len("&timestamp=") + len(timestamp) +
@@ -31,3 +37,14 @@ func WXConfigSign(jsapiTicket, nonceStr, timestamp, url string) (signature strin
 	hashsum := sha1.Sum(buf)
 	return hex.EncodeToString(hashsum[:])
 }
+
+// 微信 js-sdk wx.config 需要的参数, 包括 appId, timestamp, nonceStr, signature.
+//  url: 当前网页的URL, 如果包含 #及其后面部分, 会自动去掉
+func WXConfigParams(appId, jsapiTicket, nonceStr, timestamp, url string) map[string]string {
+	return map[string]string{
+		"appId":     appId,
+		"timestamp": timestamp,
+		"nonceStr":  nonceStr,
+		"signature": WXConfigSign(jsapiTicket, nonceStr, timestamp, url),
+	}
+}
@@ -68,17 +68,24 @@ func (clt *Client) downloadMaterialToWriter(mediaId string, writer io.Writer) (w
 		return
 	}
 
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/material/get_material?access_token="
+
 	hasRetried := false
+	failoverCount := 0
 RETRY:
-	finalURL := "https://api.weixin.qq.com/cgi-bin/material/get_material?access_token=" + url.QueryEscape(token)
+	finalURL := ((*mp.Client)(clt)).APIURL(incompleteURL) + url.QueryEscape(token)
 
 	httpResp, err := clt.HttpClient.Post(finalURL, "application/json; charset=utf-8", bytes.NewReader(requestBody))
 	if err != nil {
+		if ((*mp.Client)(clt)).APIHostFailed(incompleteURL, finalURL, err, 0, &failoverCount) {
+			goto RETRY
+		}
 		return
 	}
 	defer httpResp.Body.Close()
 
 	if httpResp.StatusCode != http.StatusOK {
+		((*mp.Client)(clt)).APIHostFailed(incompleteURL, finalURL, nil, httpResp.StatusCode, &failoverCount)
 		err = fmt.Errorf("http.Status: %s", httpResp.Status)
 		return
 	}
@@ -116,7 +123,7 @@ RETRY:
 	switch result.ErrCode {
 	case mp.ErrCodeOK:
 		return // 基本不会出现
-	case mp.ErrCodeInvalidCredential, mp.ErrCodeAccessTokenExpired: // 失效(过期)重试一次
+	case mp.ErrCodeInvalidCredential, mp.ErrCodeInvalidAccessToken, mp.ErrCodeAccessTokenExpired: // 失效(过期)重试一次
 		mp.LogInfoln("[WECHAT_RETRY] err_code:", result.ErrCode, ", err_msg:", result.ErrMsg)
 		mp.LogInfoln("[WECHAT_RETRY] current token:", token)
 
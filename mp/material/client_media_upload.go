@@ -9,6 +9,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"net/url"
 	"os"
@@ -25,6 +26,54 @@ const (
 	MaterialTypeNews  = "news"
 )
 
+// 新增永久素材, 根据 materialType 调用对应的上传方法.
+//  materialType:        素材的类型, 图片(image), 语音(voice), 视频(video), 缩略图(thumb)
+//  title, introduction: 视频素材的标题和描述, 其他类型的素材忽略这两个参数
+//  _url:                新增的图片素材的图片URL, 其他类型的素材为空
+func (clt *Client) AddMaterial(materialType, _filepath string, title, introduction string) (mediaId, _url string, err error) {
+	file, err := os.Open(_filepath)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	return clt.addMaterialFromReader(materialType, filepath.Base(_filepath), file, title, introduction)
+}
+
+// 新增永久素材, 根据 materialType 调用对应的上传方法.
+//  NOTE: 参数 filename 不是文件路径, 是指定 multipart/form-data 里面文件名称
+func (clt *Client) AddMaterialFromReader(materialType, filename string, reader io.Reader,
+	title, introduction string) (mediaId, _url string, err error) {
+
+	if filename == "" {
+		err = errors.New("empty filename")
+		return
+	}
+	if reader == nil {
+		err = errors.New("nil reader")
+		return
+	}
+	return clt.addMaterialFromReader(materialType, filename, reader, title, introduction)
+}
+
+func (clt *Client) addMaterialFromReader(materialType, filename string, reader io.Reader,
+	title, introduction string) (mediaId, _url string, err error) {
+
+	switch materialType {
+	case MaterialTypeImage, MaterialTypeThumb:
+		return clt.uploadMaterialFromReader(materialType, filename, reader)
+	case MaterialTypeVoice:
+		mediaId, err = clt.uploadVoiceFromReader(filename, reader)
+		return
+	case MaterialTypeVideo:
+		mediaId, err = clt.uploadVideoFromReader(filename, reader, title, introduction)
+		return
+	default:
+		err = fmt.Errorf("Incorrect materialType: %s", materialType)
+		return
+	}
+}
+
 // 上传多媒体图片
 func (clt *Client) UploadImage(filepath string) (mediaId, _url string, err error) {
 	return clt.uploadMaterial(MaterialTypeImage, filepath)
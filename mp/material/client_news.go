@@ -73,7 +73,18 @@ func (clt *Client) AddNews(news News) (mediaId string, err error) {
 }
 
 // 修改永久图文素材.
+//  index: 要更新的文章在图文消息中的位置(多图文消息时, 此字段才有意义), 第一篇为0
 func (clt *Client) UpdateNews(mediaId string, index int, article *Article) (err error) {
+	if mediaId == "" {
+		return errors.New("empty mediaId")
+	}
+	if index < 0 || index >= NewsArticleCountLimit {
+		return fmt.Errorf("Incorrect index: %d", index)
+	}
+	if article == nil {
+		return errors.New("nil article")
+	}
+
 	var request = struct {
 		MediaId string   `json:"media_id"`
 		Index   int      `json:"index"`
@@ -44,6 +44,30 @@ func (clt *Client) UploadImagePermanentFromReader(filename string, reader io.Rea
 	return clt.uploadImagePermanentFromReader(filename, reader)
 }
 
+// 上传图文消息内的图片, 返回图片的 URL.
+//  图文消息的具体内容中, 微信后台将过滤外部的图片链接, 图片 URL 需通过该接口上传图片获取.
+//  和 UploadImage 不同, 这里上传的图片不占用公众号的素材库中图片数量的限制, 也没有3天的有效期.
+//  图片仅支持 jpg/png 格式, 大小必须在 1MB 以下.
+func (clt *Client) UploadNewsImage(imgPath string) (url string, err error) {
+	info, err := clt.UploadImagePermanent(imgPath)
+	if err != nil {
+		return
+	}
+	url = info.URL
+	return
+}
+
+// 上传图文消息内的图片, 返回图片的 URL, 见 UploadNewsImage.
+//  NOTE: 参数 filename 不是文件路径, 是指定 multipart/form-data 里面文件名称
+func (clt *Client) UploadNewsImageFromReader(filename string, reader io.Reader) (url string, err error) {
+	info, err := clt.UploadImagePermanentFromReader(filename, reader)
+	if err != nil {
+		return
+	}
+	url = info.URL
+	return
+}
+
 func (clt *Client) uploadImagePermanentFromReader(filename string, reader io.Reader) (info ImageInfo, err error) {
 	var result struct {
 		mp.Error
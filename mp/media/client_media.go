@@ -32,7 +32,7 @@ func (clt *Client) DownloadMedia(mediaId, filepath string) (written int64, err e
 		}
 	}()
 
-	return clt.downloadMediaToWriter(mediaId, file)
+	return clt.downloadMediaToWriter(mediaDownloadURL(mediaId), file, 0, nil, nil)
 }
 
 // 下载多媒体到 io.Writer.
@@ -42,35 +42,102 @@ func (clt *Client) DownloadMediaToWriter(mediaId string, writer io.Writer) (writ
 		err = errors.New("nil writer")
 		return
 	}
-	return clt.downloadMediaToWriter(mediaId, writer)
+	return clt.downloadMediaToWriter(mediaDownloadURL(mediaId), writer, 0, nil, nil)
+}
+
+// 下载(上传)进度回调函数.
+//  written: 已经下载(上传)的字节数
+//  total:   多媒体的总字节数, 如果微信服务器没有返回 Content-Length 则为 -1
+type ProgressFunc func(written, total int64)
+
+// 多媒体的大小超过了限制
+var ErrMediaTooLarge = errors.New("the size of media exceeds the limit")
+
+// 下载多媒体到 io.Writer, 下载的过程中回调 progress, 并且限制多媒体的大小.
+//  maxSize:  多媒体的最大字节数, 超过则中止下载并返回 ErrMediaTooLarge, <= 0 表示不限制
+//  progress: 下载进度回调函数, 可以为 nil
+//  请注意, 视频文件不支持下载
+func (clt *Client) DownloadMediaWithProgress(mediaId string, writer io.Writer,
+	maxSize int64, progress ProgressFunc) (written int64, err error) {
+
+	if writer == nil {
+		err = errors.New("nil writer")
+		return
+	}
+	return clt.downloadMediaToWriter(mediaDownloadURL(mediaId), writer, maxSize, progress, nil)
+}
+
+// 下载的多媒体文件的信息, 从 http 响应头获取.
+type DownloadInfo struct {
+	ContentType   string // 比如 image/jpeg
+	Filename      string // Content-Disposition 里的文件名, 可能为空
+	ContentLength int64  // 多媒体的字节数, -1 表示未知
+}
+
+// 下载多媒体到 io.Writer, 同时返回多媒体文件的信息, 可以根据 info.Filename 或 info.ContentType 确定文件的扩展名.
+//  请注意, 视频文件不支持下载
+func (clt *Client) DownloadMediaWithInfo(mediaId string, writer io.Writer) (info *DownloadInfo, written int64, err error) {
+	if writer == nil {
+		err = errors.New("nil writer")
+		return
+	}
+
+	var downloadInfo DownloadInfo
+	if written, err = clt.downloadMediaToWriter(mediaDownloadURL(mediaId), writer, 0, nil, &downloadInfo); err != nil {
+		return
+	}
+	info = &downloadInfo
+	return
+}
+
+func mediaDownloadURL(mediaId string) string {
+	return "https://api.weixin.qq.com/cgi-bin/media/get?media_id=" + url.QueryEscape(mediaId) + "&access_token="
 }
 
 // 下载多媒体到 io.Writer.
-func (clt *Client) downloadMediaToWriter(mediaId string, writer io.Writer) (written int64, err error) {
+//  最终的 URL == incompleteURL + access_token;
+//  如果 info != nil 则把多媒体文件的信息写入 info.
+func (clt *Client) downloadMediaToWriter(incompleteURL string, writer io.Writer,
+	maxSize int64, progress ProgressFunc, info *DownloadInfo) (written int64, err error) {
+
 	token, err := clt.Token()
 	if err != nil {
 		return
 	}
 
 	hasRetried := false
+	failoverCount := 0
 RETRY:
-	finalURL := "https://api.weixin.qq.com/cgi-bin/media/get?media_id=" + url.QueryEscape(mediaId) +
-		"&access_token=" + url.QueryEscape(token)
+	finalURL := ((*mp.Client)(clt)).APIURL(incompleteURL) + url.QueryEscape(token)
 
 	httpResp, err := clt.HttpClient.Get(finalURL)
 	if err != nil {
+		if ((*mp.Client)(clt)).APIHostFailed(incompleteURL, finalURL, err, 0, &failoverCount) {
+			goto RETRY
+		}
 		return
 	}
 	defer httpResp.Body.Close()
 
 	if httpResp.StatusCode != http.StatusOK {
+		((*mp.Client)(clt)).APIHostFailed(incompleteURL, finalURL, nil, httpResp.StatusCode, &failoverCount)
 		err = fmt.Errorf("http.Status: %s", httpResp.Status)
 		return
 	}
 
 	ContentType, _, _ := mime.ParseMediaType(httpResp.Header.Get("Content-Type"))
 	if ContentType != "text/plain" && ContentType != "application/json" { // 返回的是媒体流
-		return io.Copy(writer, httpResp.Body)
+		if info != nil {
+			info.ContentType = ContentType
+			info.ContentLength = httpResp.ContentLength
+			if _, params, err := mime.ParseMediaType(httpResp.Header.Get("Content-Disposition")); err == nil {
+				info.Filename = params["filename"]
+			}
+		}
+		if maxSize <= 0 && progress == nil {
+			return io.Copy(writer, httpResp.Body)
+		}
+		return copyWithProgress(writer, httpResp.Body, httpResp.ContentLength, maxSize, progress)
 	}
 
 	// 返回的是错误信息
@@ -82,7 +149,7 @@ RETRY:
 	switch result.ErrCode {
 	case mp.ErrCodeOK:
 		return // 基本不会出现
-	case mp.ErrCodeInvalidCredential, mp.ErrCodeAccessTokenExpired: // 失效(过期)重试一次
+	case mp.ErrCodeInvalidCredential, mp.ErrCodeInvalidAccessToken, mp.ErrCodeAccessTokenExpired: // 失效(过期)重试一次
 		mp.LogInfoln("[WECHAT_RETRY] err_code:", result.ErrCode, ", err_msg:", result.ErrMsg)
 		mp.LogInfoln("[WECHAT_RETRY] current token:", token)
 
@@ -176,3 +243,45 @@ func (clt *Client) CreateVideo(mediaId, title, description string) (info *MediaI
 	info = &result.MediaInfo
 	return
 }
+
+// 从 src 复制数据到 dst, 每次写入后回调 progress, 超过 maxSize 返回 ErrMediaTooLarge.
+func copyWithProgress(dst io.Writer, src io.Reader, total, maxSize int64, progress ProgressFunc) (written int64, err error) {
+	if maxSize > 0 && total > maxSize {
+		err = ErrMediaTooLarge
+		return
+	}
+	if maxSize > 0 {
+		src = io.LimitReader(src, maxSize+1) // 多读一个字节用于判断是否超过限制
+	}
+
+	buf := make([]byte, 32<<10)
+	for {
+		nr, er := src.Read(buf)
+		if nr > 0 {
+			if maxSize > 0 && written+int64(nr) > maxSize {
+				err = ErrMediaTooLarge
+				return
+			}
+			nw, ew := dst.Write(buf[:nr])
+			written += int64(nw)
+			if ew != nil {
+				err = ew
+				return
+			}
+			if nw != nr {
+				err = io.ErrShortWrite
+				return
+			}
+			if progress != nil {
+				progress(written, total)
+			}
+		}
+		if er == io.EOF {
+			return
+		}
+		if er != nil {
+			err = er
+			return
+		}
+	}
+}
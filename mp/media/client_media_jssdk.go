@@ -0,0 +1,52 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package media
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/url"
+)
+
+// 高清语音的转码函数, 比如把 speex 格式转换为 wav 格式.
+//  把 src 里的 speex 数据转码后写入 dst, 返回写入 dst 的字节数.
+type SpeexConverter func(dst io.Writer, src io.Reader) (written int64, err error)
+
+// 下载 JSSDK 上传的高清语音素材(speex 格式, 16K 采样率)到 io.Writer.
+//  mediaId 为 JSSDK 的 uploadVoice 接口得到的 serverId.
+func (clt *Client) DownloadMediaJSSDK(mediaId string, writer io.Writer) (written int64, err error) {
+	if writer == nil {
+		err = errors.New("nil writer")
+		return
+	}
+	return clt.downloadMediaToWriter(jssdkMediaDownloadURL(mediaId), writer, 0, nil, nil)
+}
+
+// 下载 JSSDK 上传的高清语音素材, 用 converter 转码后写入 io.Writer.
+//  下载的 speex 数据先缓存在内存中, 下载成功后再调用 converter;
+//  如果 converter == nil 则等价于 DownloadMediaJSSDK.
+func (clt *Client) DownloadMediaJSSDKWithConverter(mediaId string, writer io.Writer,
+	converter SpeexConverter) (written int64, err error) {
+
+	if writer == nil {
+		err = errors.New("nil writer")
+		return
+	}
+	if converter == nil {
+		return clt.downloadMediaToWriter(jssdkMediaDownloadURL(mediaId), writer, 0, nil, nil)
+	}
+
+	var speex bytes.Buffer
+	if _, err = clt.downloadMediaToWriter(jssdkMediaDownloadURL(mediaId), &speex, 0, nil, nil); err != nil {
+		return
+	}
+	return converter(writer, &speex)
+}
+
+func jssdkMediaDownloadURL(mediaId string) string {
+	return "https://api.weixin.qq.com/cgi-bin/media/get/jssdk?media_id=" + url.QueryEscape(mediaId) + "&access_token="
+}
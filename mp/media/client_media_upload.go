@@ -7,6 +7,7 @@ package media
 
 import (
 	"errors"
+	"fmt"
 	"io"
 	"net/url"
 	"os"
@@ -83,6 +84,61 @@ func (clt *Client) UploadVideoFromReader(filename string, reader io.Reader) (inf
 	return clt.uploadMediaFromReader(MediaTypeVideo, filename, reader)
 }
 
+// 上传群发用的视频, 带标题和描述.
+//  分两步完成: 先上传视频文件得到 media_id, 然后调用 CreateVideo 得到群发消息里使用的 media_id.
+//  上传视频文件的接口不支持标题和描述, 所以不能一步完成; 如果第二步失败, 返回的 err 是 *CreateVideoError,
+//  里面有第一步得到的 media_id, 可以直接用它重新调用 CreateVideo, 不需要重新上传视频文件.
+//  title:       标题, 可以为空
+//  description: 描述, 可以为空
+func (clt *Client) UploadVideoForMass(_filepath string, title, description string) (info *MediaInfo, err error) {
+	file, err := os.Open(_filepath)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	return clt.uploadVideoForMassFromReader(filepath.Base(_filepath), file, title, description)
+}
+
+// 上传群发用的视频, 带标题和描述, 见 UploadVideoForMass.
+//  NOTE: 参数 filename 不是文件路径, 是指定 multipart/form-data 里面文件名称
+func (clt *Client) UploadVideoForMassFromReader(filename string, reader io.Reader,
+	title, description string) (info *MediaInfo, err error) {
+
+	if filename == "" {
+		err = errors.New("empty filename")
+		return
+	}
+	if reader == nil {
+		err = errors.New("nil reader")
+		return
+	}
+	return clt.uploadVideoForMassFromReader(filename, reader, title, description)
+}
+
+func (clt *Client) uploadVideoForMassFromReader(filename string, reader io.Reader,
+	title, description string) (info *MediaInfo, err error) {
+
+	videoInfo, err := clt.uploadMediaFromReader(MediaTypeVideo, filename, reader)
+	if err != nil {
+		return
+	}
+	if info, err = clt.CreateVideo(videoInfo.MediaId, title, description); err != nil {
+		err = &CreateVideoError{MediaId: videoInfo.MediaId, Err: err}
+	}
+	return
+}
+
+// UploadVideoForMass 第二步(CreateVideo)失败时返回的错误, 这个时候视频文件已经上传成功了.
+type CreateVideoError struct {
+	MediaId string // 上传视频文件得到的 media_id, 可以用来重新调用 CreateVideo
+	Err     error  // CreateVideo 失败的原因
+}
+
+func (e *CreateVideoError) Error() string {
+	return fmt.Sprintf("video uploaded with media_id %s, but CreateVideo failed: %s", e.MediaId, e.Err)
+}
+
 func (clt *Client) uploadMediaFromReader(mediaType, filename string, reader io.Reader) (info *MediaInfo, err error) {
 	var result struct {
 		mp.Error
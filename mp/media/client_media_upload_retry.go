@@ -0,0 +1,145 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package media
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 上传视频, 网络错误或者微信服务器返回的 http 状态码不是 200 时自动重新上传, 适合网络不稳定时上传大的视频文件.
+//  NOTE:
+//  1. reader 的数据会先保存到临时文件, 上传完成后删除, 这样重新上传的时候不需要 reader 可以重复读取;
+//  2. 微信服务器不支持断点续传, 所以重新上传是从临时文件的开头重新发送整个请求;
+//  3. 重新上传之间会等待一段时间, 第 n 次重新上传等待 n 秒;
+//  4. 每次上传都是调用 PostMultipartForm, 和其他的 api 一样经过 Client.Hook 和 Client.RateLimiter,
+//     access_token 过期等情况由 PostMultipartForm 处理, 不算重新上传的次数.
+//
+//  filename:   指定 multipart/form-data 里面文件名称, 不是文件路径
+//  maxRetries: 最多重新上传的次数, <= 0 表示不重新上传
+//  progress:   上传进度回调函数, 可以为 nil, 每次重新上传 written 会从 0 开始
+func (clt *Client) UploadVideoWithRetry(filename string, reader io.Reader, maxRetries int, progress ProgressFunc) (info *MediaInfo, err error) {
+	if filename == "" {
+		err = errors.New("empty filename")
+		return
+	}
+	if reader == nil {
+		err = errors.New("nil reader")
+		return
+	}
+
+	// 保存到临时文件
+	file, err := ioutil.TempFile("", "wechat-media-")
+	if err != nil {
+		return
+	}
+	defer func() {
+		file.Close()
+		os.Remove(file.Name())
+	}()
+
+	sizeLimit := SizeLimits[MediaTypeVideo]
+	size, err := io.Copy(file, io.LimitReader(reader, sizeLimit+1))
+	if err != nil {
+		return
+	}
+	if size > sizeLimit {
+		err = &ValidationError{
+			MediaType: MediaTypeVideo,
+			Size:      -1,
+			SizeLimit: sizeLimit,
+			Reason:    fmt.Sprintf("size exceeds limit %d", sizeLimit),
+		}
+		return
+	}
+	if _, err = file.Seek(0, os.SEEK_SET); err != nil {
+		return
+	}
+	if _, _, err = validate(MediaTypeVideo, file); err != nil {
+		return
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/media/upload?type=video&access_token="
+	fields := []mp.MultipartFormField{{
+		ContentType: 0,
+		FieldName:   "media",
+		FileName:    filename,
+		Value:       &progressReader{reader: file, total: size, progress: progress},
+	}}
+
+	for retries := 0; ; retries++ {
+		if retries > 0 {
+			time.Sleep(time.Duration(retries) * time.Second)
+		}
+
+		// PostMultipartForm 从 Value 的当前位置开始发送
+		if _, err = file.Seek(0, os.SEEK_SET); err != nil {
+			return
+		}
+
+		var result struct {
+			mp.Error
+			MediaInfo
+		}
+		if err = ((*mp.Client)(clt)).PostMultipartForm(incompleteURL, fields, &result); err != nil {
+			if retries < maxRetries && isUploadRetryable(err) {
+				mp.LogInfoln("[WECHAT_RETRY] upload video failed:", err, ", retries:", retries+1)
+				continue
+			}
+			return
+		}
+		if result.ErrCode != mp.ErrCodeOK {
+			err = &result.Error
+			return
+		}
+		info = &result.MediaInfo
+		return
+	}
+}
+
+// 上传失败后是否可以重新上传, 网络错误, http 状态码不是 200 等都重新上传;
+// 频率限制和微信服务器返回的错误重新上传也不会成功.
+func isUploadRetryable(err error) bool {
+	switch err.(type) {
+	case *mp.RateLimitError, *mp.Error:
+		return false
+	}
+	return true
+}
+
+// 读取数据的同时回调 progress.
+//  实现了 io.Seeker, PostMultipartForm 可以流式发送, 重新发送的时候 written 回到 0.
+type progressReader struct {
+	reader   io.ReadSeeker
+	written  int64
+	total    int64
+	progress ProgressFunc
+}
+
+func (r *progressReader) Read(p []byte) (n int, err error) {
+	n, err = r.reader.Read(p)
+	if n > 0 {
+		r.written += int64(n)
+		if r.progress != nil {
+			r.progress(r.written, r.total)
+		}
+	}
+	return
+}
+
+func (r *progressReader) Seek(offset int64, whence int) (pos int64, err error) {
+	if pos, err = r.reader.Seek(offset, whence); err != nil {
+		return
+	}
+	r.written = pos
+	return
+}
@@ -0,0 +1,76 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package media
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// 上传网络上的多媒体文件, 下载的数据直接写入上传请求, 不会保存到磁盘.
+//  mediaType: MediaTypeImage, MediaTypeVoice, MediaTypeVideo, MediaTypeThumb
+//  sourceURL: 多媒体文件的 URL
+//  上传前会用 Validate 检查文件格式和大小, 比如 MediaTypeThumb 只支持 jpg, MediaTypeVideo 只支持 mp4.
+func (clt *Client) UploadFromURL(mediaType, sourceURL string) (info *MediaInfo, err error) {
+	if sourceURL == "" {
+		err = errors.New("empty sourceURL")
+		return
+	}
+	if _, ok := SizeLimits[mediaType]; !ok {
+		err = fmt.Errorf("unsupported media type: %s", mediaType)
+		return
+	}
+
+	u, err := url.Parse(sourceURL)
+	if err != nil {
+		return
+	}
+
+	httpResp, err := clt.HttpClient.Get(sourceURL)
+	if err != nil {
+		return
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		err = fmt.Errorf("http.Status: %s", httpResp.Status)
+		return
+	}
+
+	if sizeLimit := SizeLimits[mediaType]; httpResp.ContentLength > sizeLimit {
+		err = &ValidationError{
+			MediaType: mediaType,
+			Size:      httpResp.ContentLength,
+			SizeLimit: sizeLimit,
+			Reason:    fmt.Sprintf("size %d exceeds limit %d", httpResp.ContentLength, sizeLimit),
+		}
+		return
+	}
+
+	// 不把整个文件读入内存, 文件头检查格式, Content-Length(如果有)和读取时计数检查大小
+	reader, format, err := validateStream(mediaType, httpResp.Body)
+	if err != nil {
+		return
+	}
+
+	// 微信服务器会检查文件的扩展名, 如果 URL 里没有扩展名则根据文件格式加上
+	filename := path.Base(u.Path)
+	if filename == "" || filename == "." || filename == "/" {
+		filename = "media"
+	}
+	if !strings.Contains(filename, ".") {
+		filename += "." + format
+	}
+
+	if mediaType == MediaTypeThumb {
+		return clt.uploadThumbFromReader(filename, reader)
+	}
+	return clt.uploadMediaFromReader(mediaType, filename, reader)
+}
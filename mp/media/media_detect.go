@@ -0,0 +1,89 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package media
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// 文件扩展名对应的格式, 文件头部不能识别格式的时候使用
+var extFormats = map[string]string{
+	".jpg":  FormatJPEG,
+	".jpeg": FormatJPEG,
+	".png":  FormatPNG,
+	".gif":  FormatGIF,
+	".bmp":  FormatBMP,
+	".amr":  FormatAMR,
+	".mp3":  FormatMP3,
+	".mp4":  FormatMP4,
+}
+
+// 根据文件头部的 magic bytes 和文件扩展名判断多媒体类型, 读取后 r 会 Seek 回原来的位置.
+//  图片格式返回 MediaTypeImage, 但是文件名(不含扩展名)以 "thumb" 开头或结尾的 jpg 文件返回 MediaTypeThumb;
+//  amr, mp3 返回 MediaTypeVoice; mp4 返回 MediaTypeVideo.
+func DetectType(filename string, r io.ReadSeeker) (mediaType string, err error) {
+	format := FormatUnknown
+	if r != nil {
+		var offset int64
+		if offset, err = r.Seek(0, io.SeekCurrent); err != nil {
+			return
+		}
+		head := make([]byte, sniffLen)
+		n, err2 := io.ReadFull(r, head)
+		if err2 != nil && err2 != io.EOF && err2 != io.ErrUnexpectedEOF {
+			err = err2
+			return
+		}
+		if _, err = r.Seek(offset, io.SeekStart); err != nil {
+			return
+		}
+		format = DetectFormat(head[:n])
+	}
+
+	ext := strings.ToLower(filepath.Ext(filename))
+	if format == FormatUnknown {
+		format = extFormats[ext]
+	}
+
+	switch format {
+	case FormatJPEG:
+		name := strings.ToLower(strings.TrimSuffix(filepath.Base(filename), filepath.Ext(filename)))
+		if strings.HasPrefix(name, "thumb") || strings.HasSuffix(name, "thumb") {
+			return MediaTypeThumb, nil
+		}
+		return MediaTypeImage, nil
+	case FormatPNG, FormatGIF, FormatBMP:
+		return MediaTypeImage, nil
+	case FormatAMR, FormatMP3:
+		return MediaTypeVoice, nil
+	case FormatMP4:
+		return MediaTypeVideo, nil
+	}
+	err = fmt.Errorf("can not detect media type of %s", filename)
+	return
+}
+
+// 上传多媒体文件, 根据 DetectType 判断多媒体类型.
+func (clt *Client) UploadMediaFromFile(_filepath string) (info *MediaInfo, err error) {
+	file, err := os.Open(_filepath)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	mediaType, err := DetectType(_filepath, file)
+	if err != nil {
+		return
+	}
+	if mediaType == MediaTypeThumb {
+		return clt.uploadThumbFromReader(filepath.Base(_filepath), file)
+	}
+	return clt.uploadMediaFromReader(mediaType, filepath.Base(_filepath), file)
+}
@@ -0,0 +1,78 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package media
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// 多媒体文件的格式, 通过文件头部的 magic bytes 判断
+const (
+	FormatUnknown = ""
+	FormatJPEG    = "jpg"
+	FormatPNG     = "png"
+	FormatGIF     = "gif"
+	FormatBMP     = "bmp"
+	FormatAMR     = "amr"
+	FormatMP3     = "mp3"
+	FormatMP4     = "mp4"
+)
+
+// 判断文件头部数据的格式需要的最大字节数
+const sniffLen = 12
+
+// 根据文件头部的数据判断多媒体文件的格式, 不能识别返回 FormatUnknown.
+func DetectFormat(head []byte) string {
+	switch {
+	case bytes.HasPrefix(head, []byte{0xFF, 0xD8, 0xFF}):
+		return FormatJPEG
+	case bytes.HasPrefix(head, []byte("\x89PNG\r\n\x1a\n")):
+		return FormatPNG
+	case bytes.HasPrefix(head, []byte("GIF87a")), bytes.HasPrefix(head, []byte("GIF89a")):
+		return FormatGIF
+	case bytes.HasPrefix(head, []byte("BM")):
+		return FormatBMP
+	case bytes.HasPrefix(head, []byte("#!AMR")):
+		return FormatAMR
+	case bytes.HasPrefix(head, []byte("ID3")), len(head) >= 2 && head[0] == 0xFF && head[1]&0xE0 == 0xE0:
+		return FormatMP3
+	case len(head) >= 8 && bytes.Equal(head[4:8], []byte("ftyp")):
+		return FormatMP4
+	}
+	return FormatUnknown
+}
+
+// 各种多媒体类型支持的文件格式
+var mediaTypeFormats = map[string][]string{
+	MediaTypeImage: {FormatJPEG, FormatPNG, FormatGIF, FormatBMP},
+	MediaTypeVoice: {FormatAMR, FormatMP3},
+	MediaTypeVideo: {FormatMP4},
+	MediaTypeThumb: {FormatJPEG},
+}
+
+// 检查 format 是否是 mediaType 支持的格式, 不支持返回 *ValidationError.
+func checkFormat(mediaType, format string) error {
+	formats, ok := mediaTypeFormats[mediaType]
+	if !ok {
+		return fmt.Errorf("unsupported media type: %s", mediaType)
+	}
+	for _, f := range formats {
+		if f == format {
+			return nil
+		}
+	}
+
+	reason := fmt.Sprintf("file format %s is not supported, supported formats: %v", format, formats)
+	if format == FormatUnknown {
+		reason = fmt.Sprintf("unknown file format, supported formats: %v", formats)
+	}
+	return &ValidationError{
+		MediaType: mediaType,
+		Format:    format,
+		Reason:    reason,
+	}
+}
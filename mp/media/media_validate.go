@@ -0,0 +1,195 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package media
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+)
+
+// 各种多媒体类型的文件大小限制, 单位字节, 微信调整限制的时候可以修改.
+var SizeLimits = map[string]int64{
+	MediaTypeImage: 1 << 20,  // 1MB
+	MediaTypeVoice: 2 << 20,  // 2MB
+	MediaTypeVideo: 10 << 20, // 10MB
+	MediaTypeThumb: 64 << 10, // 64KB
+}
+
+// 上传前检查多媒体文件不满足微信限制的错误.
+type ValidationError struct {
+	MediaType string
+	Format    string // 检测到的文件格式, FormatUnknown 表示不能识别
+	Size      int64  // 文件大小, 超过限制时有效, -1 表示超过了限制但是不知道具体大小
+	SizeLimit int64  // 文件大小限制, 超过限制时有效
+	Reason    string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid %s media: %s", e.MediaType, e.Reason)
+}
+
+// 检查将要上传的多媒体文件是否满足微信的格式和大小限制, 在请求微信服务器之前发现错误.
+//  因为检查的时候需要读取 reader 的数据, 所以检查通过后应该用返回的 validated 替代 reader 上传.
+//  如果 reader 实现了 io.Seeker 则通过 Seek 获取文件大小, 否则至多读取 SizeLimits[mediaType]+1 字节到内存.
+//  不满足限制返回 *ValidationError.
+func Validate(mediaType string, reader io.Reader) (validated io.Reader, err error) {
+	validated, _, err = validate(mediaType, reader)
+	return
+}
+
+func validate(mediaType string, reader io.Reader) (validated io.Reader, format string, err error) {
+	sizeLimit, ok := SizeLimits[mediaType]
+	if !ok {
+		err = fmt.Errorf("unsupported media type: %s", mediaType)
+		return
+	}
+
+	var head []byte
+	if seeker, ok := reader.(io.ReadSeeker); ok {
+		var offset, end int64
+		if offset, err = seeker.Seek(0, os.SEEK_CUR); err != nil {
+			return
+		}
+		if end, err = seeker.Seek(0, os.SEEK_END); err != nil {
+			return
+		}
+		if _, err = seeker.Seek(offset, os.SEEK_SET); err != nil {
+			return
+		}
+		if size := end - offset; size > sizeLimit {
+			err = &ValidationError{
+				MediaType: mediaType,
+				Size:      size,
+				SizeLimit: sizeLimit,
+				Reason:    fmt.Sprintf("size %d exceeds limit %d", size, sizeLimit),
+			}
+			return
+		}
+
+		head = make([]byte, sniffLen)
+		n, err := io.ReadFull(seeker, head)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return nil, "", err
+		}
+		head = head[:n]
+		if _, err = seeker.Seek(offset, os.SEEK_SET); err != nil {
+			return nil, "", err
+		}
+		validated = seeker
+	} else {
+		var data []byte
+		if data, err = ioutil.ReadAll(io.LimitReader(reader, sizeLimit+1)); err != nil {
+			return
+		}
+		if int64(len(data)) > sizeLimit {
+			err = &ValidationError{
+				MediaType: mediaType,
+				Size:      -1,
+				SizeLimit: sizeLimit,
+				Reason:    fmt.Sprintf("size exceeds limit %d", sizeLimit),
+			}
+			return
+		}
+		head = data
+		validated = bytes.NewReader(data)
+	}
+
+	format = DetectFormat(head)
+	if err = checkFormat(mediaType, format); err != nil {
+		validated = nil
+		return
+	}
+	return
+}
+
+// 上传多媒体文件, 上传前调用 Validate 检查文件格式和大小.
+//  mediaType: MediaTypeImage, MediaTypeVoice, MediaTypeVideo, MediaTypeThumb
+//  NOTE: 参数 filename 不是文件路径, 是指定 multipart/form-data 里面文件名称
+func (clt *Client) UploadWithValidation(mediaType, filename string, reader io.Reader) (info *MediaInfo, err error) {
+	if filename == "" {
+		err = errors.New("empty filename")
+		return
+	}
+	if reader == nil {
+		err = errors.New("nil reader")
+		return
+	}
+
+	if reader, err = Validate(mediaType, reader); err != nil {
+		return
+	}
+	if mediaType == MediaTypeThumb {
+		return clt.uploadThumbFromReader(filename, reader)
+	}
+	return clt.uploadMediaFromReader(mediaType, filename, reader)
+}
+
+// 流式检查, 用于不能 Seek 并且不想读入内存的 reader(比如 http 响应的 Body).
+//  只用 Peek 读取文件头检查格式, 文件大小在读取返回的 validated 的过程中检查,
+//  超过限制时 validated.Read 返回 *ValidationError.
+func validateStream(mediaType string, reader io.Reader) (validated io.Reader, format string, err error) {
+	sizeLimit, ok := SizeLimits[mediaType]
+	if !ok {
+		err = fmt.Errorf("unsupported media type: %s", mediaType)
+		return
+	}
+
+	bufReader := bufio.NewReader(reader)
+	head, err := bufReader.Peek(sniffLen)
+	if err != nil && err != io.EOF {
+		return nil, "", err
+	}
+	format = DetectFormat(head)
+	if err = checkFormat(mediaType, format); err != nil {
+		return
+	}
+	validated = &sizeLimitReader{
+		reader:    bufReader,
+		mediaType: mediaType,
+		remain:    sizeLimit,
+		sizeLimit: sizeLimit,
+	}
+	return
+}
+
+// 读取超过 sizeLimit 字节时返回 *ValidationError.
+type sizeLimitReader struct {
+	reader    io.Reader
+	mediaType string
+	remain    int64
+	sizeLimit int64
+}
+
+func (r *sizeLimitReader) Read(p []byte) (n int, err error) {
+	if r.remain < 0 {
+		return 0, r.validationError()
+	}
+	if int64(len(p)) > r.remain+1 {
+		p = p[:r.remain+1] // 多读一个字节用于判断是否超过限制
+	}
+	n, err = r.reader.Read(p)
+	if int64(n) > r.remain {
+		n = int(r.remain)
+		r.remain = -1
+		return n, r.validationError()
+	}
+	r.remain -= int64(n)
+	return
+}
+
+func (r *sizeLimitReader) validationError() error {
+	return &ValidationError{
+		MediaType: r.mediaType,
+		Size:      -1,
+		SizeLimit: r.sizeLimit,
+		Reason:    fmt.Sprintf("size exceeds limit %d", r.sizeLimit),
+	}
+}
@@ -0,0 +1,232 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package media
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	ArticleTitleLengthLimit  = 64  // 标题的最大字符数
+	ArticleDigestLengthLimit = 120 // 摘要的最大字符数
+)
+
+var (
+	imgSrcRegexp = regexp.MustCompile(`(?i)(<img\b[^>]*?\bsrc\s*=\s*["'])([^"']+)(["'])`)
+	scriptRegexp = regexp.MustCompile(`(?is)<(script|style)\b.*?</(script|style)\s*>`)
+)
+
+// 图文素材的构造器.
+//
+//  builder := clt.NewNewsBuilder()
+//  builder.RewriteContentImages(true)
+//  builder.AddArticle(article1, "/path/to/cover1.jpg")
+//  builder.AddArticle(article2, "") // article2.ThumbMediaId 已经设置
+//  info, err := builder.Create()
+type NewsBuilder struct {
+	clt                  *Client
+	articles             []Article
+	coverPaths           []string // 和 articles 一一对应, 为空表示 ThumbMediaId 已经设置
+	rewriteContentImages bool
+	localImageDir        string // 不为空时允许 <img> 的 src 是这个目录下的本地文件
+}
+
+func (clt *Client) NewNewsBuilder() *NewsBuilder {
+	return &NewsBuilder{
+		clt: clt,
+	}
+}
+
+// 设置是否把 Content 里 <img> 的 src 替换为通过 UploadNewsImage 上传后得到的 URL.
+//  默认只处理 http(s) URL, 已经是微信服务器的图片, data: 等其他 scheme 以及本地路径都保持不变;
+//  如果需要上传本地文件, 请调用 LocalImageDir 指定本地图片所在的目录.
+func (builder *NewsBuilder) RewriteContentImages(b bool) *NewsBuilder {
+	builder.rewriteContentImages = b
+	return builder
+}
+
+// 允许 Content 里 <img> 的 src 是本地文件, src 是相对于 dir 的路径, 不能是绝对路径, 也不能访问 dir 以外的文件.
+//  NOTE: Content 一般来自用户编辑的 HTML, 不要把 dir 设置为包含敏感文件的目录.
+func (builder *NewsBuilder) LocalImageDir(dir string) *NewsBuilder {
+	builder.localImageDir = dir
+	return builder
+}
+
+// 添加一篇文章.
+//  coverPath: 封面图片的本地路径, 不为空时上传该图片并设置 article.ThumbMediaId
+func (builder *NewsBuilder) AddArticle(article Article, coverPath string) *NewsBuilder {
+	builder.articles = append(builder.articles, article)
+	builder.coverPaths = append(builder.coverPaths, coverPath)
+	return builder
+}
+
+// 检查文章, 上传封面和正文里的图片, 返回可以直接用于 CreateNews 的文章列表.
+func (builder *NewsBuilder) Build() (articles []Article, err error) {
+	if len(builder.articles) <= 0 {
+		err = errors.New("图文素材是空的")
+		return
+	}
+	if len(builder.articles) > NewsArticleCountLimit {
+		err = fmt.Errorf("图文素材的文章个数不能超过 %d, 现在为 %d", NewsArticleCountLimit, len(builder.articles))
+		return
+	}
+	for i := range builder.articles {
+		if err = checkArticle(&builder.articles[i], builder.coverPaths[i]); err != nil {
+			err = fmt.Errorf("第 %d 篇文章: %s", i+1, err.Error())
+			return
+		}
+	}
+
+	articles = make([]Article, len(builder.articles))
+	copy(articles, builder.articles)
+
+	imageURLs := make(map[string]string) // 已经上传的图片, src ==> url
+	for i := range articles {
+		article := &articles[i]
+
+		if coverPath := builder.coverPaths[i]; coverPath != "" {
+			var info *MediaInfo
+			if info, err = builder.clt.UploadThumb(coverPath); err != nil {
+				return
+			}
+			article.ThumbMediaId = info.MediaId
+		}
+
+		article.Content = sanitizeContent(article.Content)
+		if builder.rewriteContentImages {
+			if article.Content, err = builder.rewriteImages(article.Content, imageURLs); err != nil {
+				return
+			}
+		}
+	}
+	return
+}
+
+// Build 之后调用 CreateNews 创建图文素材.
+func (builder *NewsBuilder) Create() (info *MediaInfo, err error) {
+	articles, err := builder.Build()
+	if err != nil {
+		return
+	}
+	return builder.clt.CreateNews(articles)
+}
+
+func checkArticle(article *Article, coverPath string) error {
+	if article.Title == "" {
+		return errors.New("标题不能为空")
+	}
+	if n := utf8.RuneCountInString(article.Title); n > ArticleTitleLengthLimit {
+		return fmt.Errorf("标题不能超过 %d 个字符, 现在为 %d", ArticleTitleLengthLimit, n)
+	}
+	if n := utf8.RuneCountInString(article.Digest); n > ArticleDigestLengthLimit {
+		return fmt.Errorf("摘要不能超过 %d 个字符, 现在为 %d", ArticleDigestLengthLimit, n)
+	}
+	if article.Content == "" {
+		return errors.New("内容不能为空")
+	}
+	if article.ThumbMediaId == "" && coverPath == "" {
+		return errors.New("没有设置封面")
+	}
+	return nil
+}
+
+// 删除 <script> 和 <style>, 微信会拒绝或者过滤这些标签.
+func sanitizeContent(content string) string {
+	return scriptRegexp.ReplaceAllString(content, "")
+}
+
+func (builder *NewsBuilder) rewriteImages(content string, imageURLs map[string]string) (string, error) {
+	var err error
+	content = imgSrcRegexp.ReplaceAllStringFunc(content, func(s string) string {
+		if err != nil {
+			return s
+		}
+		sub := imgSrcRegexp.FindStringSubmatch(s)
+		src := sub[2]
+		if isWechatImageURL(src) {
+			return s
+		}
+		imageURL, ok := imageURLs[src]
+		if !ok {
+			if imageURL, err = builder.uploadImage(src); err != nil {
+				return s
+			}
+			imageURLs[src] = imageURL
+		}
+		if imageURL == "" { // 不处理的 src
+			return s
+		}
+		return sub[1] + imageURL + sub[3]
+	})
+	return content, err
+}
+
+// 上传 src 指向的图片, 不处理的 src 返回空的 imageURL.
+func (builder *NewsBuilder) uploadImage(src string) (imageURL string, err error) {
+	if !strings.HasPrefix(src, "http://") && !strings.HasPrefix(src, "https://") {
+		_filepath, ok := builder.localImagePath(src)
+		if !ok {
+			return
+		}
+		return builder.clt.UploadNewsImage(_filepath)
+	}
+
+	u, err := url.Parse(src)
+	if err != nil {
+		return
+	}
+	httpResp, err := builder.clt.HttpClient.Get(src)
+	if err != nil {
+		return
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		err = fmt.Errorf("http.Status: %s", httpResp.Status)
+		return
+	}
+
+	filename := path.Base(u.Path)
+	if filename == "" || filename == "/" || filename == "." {
+		filename = "image.jpg"
+	}
+	return builder.clt.UploadNewsImageFromReader(filename, httpResp.Body)
+}
+
+func isWechatImageURL(src string) bool {
+	u, err := url.Parse(src)
+	if err != nil {
+		return false
+	}
+	return u.Host == "mmbiz.qpic.cn" || u.Host == "mmbiz.qlogo.cn"
+}
+
+// 把 src 转换为 localImageDir 下的文件路径, 没有设置 localImageDir, src 带有 scheme,
+// 是绝对路径或者在 localImageDir 以外时返回 false.
+func (builder *NewsBuilder) localImagePath(src string) (_filepath string, ok bool) {
+	if builder.localImageDir == "" {
+		return
+	}
+	if u, err := url.Parse(src); err != nil || u.Scheme != "" || u.Host != "" {
+		return
+	}
+	if filepath.IsAbs(src) || strings.HasPrefix(src, "/") || strings.HasPrefix(src, "\\") {
+		return
+	}
+	rel := filepath.Clean(filepath.FromSlash(src))
+	if rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return
+	}
+	return filepath.Join(builder.localImageDir, rel), true
+}
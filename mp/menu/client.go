@@ -19,6 +19,10 @@ func NewClient(srv mp.AccessTokenServer, clt *http.Client) *Client {
 
 // 创建自定义菜单.
 func (clt *Client) CreateMenu(menu Menu) (err error) {
+	if err = menu.CheckValid(); err != nil {
+		return
+	}
+
 	var result mp.Error
 
 	incompleteURL := "https://api.weixin.qq.com/cgi-bin/menu/create?access_token="
@@ -0,0 +1,159 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package menu
+
+import (
+	"errors"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+const (
+	MatchRuleSexMale   = "1" // 男
+	MatchRuleSexFemale = "2" // 女
+)
+
+const (
+	MatchRuleClientPlatformTypeIOS     = "1" // IOS
+	MatchRuleClientPlatformTypeAndroid = "2" // Android
+	MatchRuleClientPlatformTypeOthers  = "3" // Others
+)
+
+// 个性化菜单的菜单匹配规则, 字段都为空的匹配规则是非法的.
+//  country, province, city 的地区信息可以参考 http://mp.weixin.qq.com/wiki/static/assets/870a3c2a14e97b3e74fde5e88fa47717.zip,
+//  设置了 province 则必须设置 country, 设置了 city 则必须设置 province.
+type MatchRule struct {
+	TagId              string `json:"tag_id,omitempty"`               // 非必须; 用户标签的id, 可通过用户标签管理接口获取
+	GroupId            string `json:"group_id,omitempty"`             // 非必须; 用户分组id, 已经被 tag_id 替代
+	Sex                string `json:"sex,omitempty"`                  // 非必须; 性别: 男(1)女(2), 不填则不做匹配
+	Country            string `json:"country,omitempty"`              // 非必须; 国家信息, 是用户在微信中设置的地区
+	Province           string `json:"province,omitempty"`             // 非必须; 省份信息, 是用户在微信中设置的地区
+	City               string `json:"city,omitempty"`                 // 非必须; 城市信息, 是用户在微信中设置的地区
+	ClientPlatformType string `json:"client_platform_type,omitempty"` // 非必须; 客户端版本, IOS(1), Android(2), Others(3), 不填则不做匹配
+	Language           string `json:"language,omitempty"`             // 非必须; 语言信息, 是用户在微信中设置的语言, 比如 zh_CN
+}
+
+func (rule *MatchRule) checkValid() error {
+	if *rule == (MatchRule{}) {
+		return errors.New("matchrule 至少要设置一个字段")
+	}
+	if rule.Province != "" && rule.Country == "" {
+		return errors.New("matchrule 设置了 province 则必须设置 country")
+	}
+	if rule.City != "" && rule.Province == "" {
+		return errors.New("matchrule 设置了 city 则必须设置 province")
+	}
+	return nil
+}
+
+// 创建个性化菜单, 返回个性化菜单的id.
+//  menu.MatchRule 不能为 nil.
+func (clt *Client) AddConditionalMenu(menu Menu) (menuId int64, err error) {
+	if menu.MatchRule == nil {
+		err = errors.New("nil menu.MatchRule")
+		return
+	}
+	if err = menu.MatchRule.checkValid(); err != nil {
+		return
+	}
+	if err = menu.CheckValid(); err != nil {
+		return
+	}
+	menu.MenuId = 0
+
+	var result struct {
+		mp.Error
+		MenuId int64 `json:"menuid,string"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/menu/addconditional?access_token="
+	if err = ((*mp.Client)(clt)).PostJSON(incompleteURL, &menu, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	menuId = result.MenuId
+	return
+}
+
+// 删除个性化菜单.
+func (clt *Client) DeleteConditionalMenu(menuId int64) (err error) {
+	var request = struct {
+		MenuId int64 `json:"menuid,string"`
+	}{
+		MenuId: menuId,
+	}
+
+	var result mp.Error
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/menu/delconditional?access_token="
+	if err = ((*mp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
+
+// 测试个性化菜单匹配结果, 返回该用户看到的菜单.
+//  userId: 可以是粉丝的 OpenID, 也可以是粉丝的微信号
+func (clt *Client) TryMatchMenu(userId string) (menu Menu, err error) {
+	if userId == "" {
+		err = errors.New("empty userId")
+		return
+	}
+
+	var request = struct {
+		UserId string `json:"user_id"`
+	}{
+		UserId: userId,
+	}
+
+	var result struct {
+		mp.Error
+		Menu
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/menu/trymatch?access_token="
+	if err = ((*mp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	menu = result.Menu
+	return
+}
+
+// 获取默认菜单和全部个性化菜单.
+func (clt *Client) GetAllMenu() (menu Menu, conditionalMenus []Menu, err error) {
+	var result struct {
+		mp.Error
+		Menu             Menu   `json:"menu"`
+		ConditionalMenus []Menu `json:"conditionalmenu"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/menu/get?access_token="
+	if err = ((*mp.Client)(clt)).GetJSON(incompleteURL, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	menu = result.Menu
+	conditionalMenus = result.ConditionalMenus
+	return
+}
@@ -13,6 +13,8 @@ const (
 	EventTypeClick = "CLICK" // 点击菜单拉取消息时的事件推送
 	EventTypeView  = "VIEW"  // 点击菜单跳转链接时的事件推送
 
+	EventTypeViewMiniprogram = "view_miniprogram" // 点击菜单跳转小程序的事件推送
+
 	// 请注意, 下面的事件仅支持微信iPhone5.4.1以上版本, 和Android5.4以上版本的微信用户,
 	// 旧版本微信用户点击后将没有回应, 开发者也不能正常接收到事件推送.
 	EventTypeScanCodePush    = "scancode_push"      // scancode_push: 扫码推事件的事件推送
@@ -45,8 +47,9 @@ type ViewEvent struct {
 	XMLName struct{} `xml:"xml" json:"-"`
 	mp.MessageHeader
 
-	Event    string `xml:"Event"    json:"Event"`    // 事件类型, VIEW
-	EventKey string `xml:"EventKey" json:"EventKey"` // 事件KEY值, 设置的跳转URL
+	Event    string `xml:"Event"            json:"Event"`            // 事件类型, VIEW
+	EventKey string `xml:"EventKey"         json:"EventKey"`         // 事件KEY值, 设置的跳转URL
+	MenuId   int64  `xml:"MenuId,omitempty" json:"MenuId,omitempty"` // 菜单ID, 如果是个性化菜单, 则可以通过这个字段, 知道是哪个规则的菜单被点击了
 }
 
 func GetViewEvent(msg *mp.MixedMessage) *ViewEvent {
@@ -54,6 +57,26 @@ func GetViewEvent(msg *mp.MixedMessage) *ViewEvent {
 		MessageHeader: msg.MessageHeader,
 		Event:         msg.Event,
 		EventKey:      msg.EventKey,
+		MenuId:        msg.MenuId,
+	}
+}
+
+// 点击菜单跳转小程序的事件推送
+type ViewMiniprogramEvent struct {
+	XMLName struct{} `xml:"xml" json:"-"`
+	mp.MessageHeader
+
+	Event    string `xml:"Event"            json:"Event"`            // 事件类型, view_miniprogram
+	EventKey string `xml:"EventKey"         json:"EventKey"`         // 事件KEY值, 跳转的小程序路径
+	MenuId   int64  `xml:"MenuId,omitempty" json:"MenuId,omitempty"` // 菜单ID, 如果是个性化菜单, 则可以通过这个字段, 知道是哪个规则的菜单被点击了
+}
+
+func GetViewMiniprogramEvent(msg *mp.MixedMessage) *ViewMiniprogramEvent {
+	return &ViewMiniprogramEvent{
+		MessageHeader: msg.MessageHeader,
+		Event:         msg.Event,
+		EventKey:      msg.EventKey,
+		MenuId:        msg.MenuId,
 	}
 }
 
@@ -5,6 +5,11 @@
 
 package menu
 
+import (
+	"errors"
+	"fmt"
+)
+
 const (
 	MenuButtonCountLimit    = 3 // 一级菜单最多包含 3 个按钮
 	SubMenuButtonCountLimit = 5 // 二级菜单最多包含 5 个按钮
@@ -46,10 +51,14 @@ const (
 	// 它们是没有事件推送的, 能力相对受限, 其他类型的公众号不必使用.
 	ButtonTypeMediaId     = "media_id"     // 下发消息
 	ButtonTypeViewLimited = "view_limited" // 跳转图文消息URL
+
+	ButtonTypeMiniProgram = "miniprogram" // 跳转小程序, 不支持小程序的老版本客户端将打开 URL
 )
 
 type Menu struct {
-	Buttons []Button `json:"button,omitempty"` // 一级菜单数组, 个数应为1~3个
+	Buttons   []Button   `json:"button,omitempty"`    // 一级菜单数组, 个数应为1~3个
+	MatchRule *MatchRule `json:"matchrule,omitempty"` // 个性化菜单的菜单匹配规则, 默认菜单为 nil
+	MenuId    int64      `json:"menuid,omitempty"`    // 个性化菜单的id, 只在获取菜单时有效, 创建菜单时不需要设置
 }
 
 // 菜单的按钮
@@ -59,6 +68,8 @@ type Button struct {
 	Key        string   `json:"key,omitempty"`        // 非必须; 菜单KEY值, 用于消息接口推送, 不超过128字节
 	URL        string   `json:"url,omitempty"`        // 非必须; 网页链接, 用户点击菜单可打开链接, 不超过256字节
 	MediaId    string   `json:"media_id,omitempty"`   // 非必须; 调用新增永久素材接口返回的合法media_id
+	AppId      string   `json:"appid,omitempty"`      // 非必须; 小程序的appid, 仅 miniprogram 类型
+	PagePath   string   `json:"pagepath,omitempty"`   // 非必须; 小程序的页面路径, 仅 miniprogram 类型
 	SubButtons []Button `json:"sub_button,omitempty"` // 非必须; 二级菜单数组, 个数应为1~5个
 }
 
@@ -71,6 +82,8 @@ func (btn *Button) SetAsSubMenuButton(name string, subButtons []Button) {
 	btn.Key = ""
 	btn.URL = ""
 	btn.MediaId = ""
+	btn.AppId = ""
+	btn.PagePath = ""
 }
 
 // 设置 btn 指向的 Button 为 click 类型按钮
@@ -81,6 +94,8 @@ func (btn *Button) SetAsClickButton(name, key string) {
 
 	btn.URL = ""
 	btn.MediaId = ""
+	btn.AppId = ""
+	btn.PagePath = ""
 	btn.SubButtons = nil
 }
 
@@ -92,6 +107,8 @@ func (btn *Button) SetAsViewButton(name, url string) {
 
 	btn.Key = ""
 	btn.MediaId = ""
+	btn.AppId = ""
+	btn.PagePath = ""
 	btn.SubButtons = nil
 }
 
@@ -103,6 +120,8 @@ func (btn *Button) SetAsScanCodePushButton(name, key string) {
 
 	btn.URL = ""
 	btn.MediaId = ""
+	btn.AppId = ""
+	btn.PagePath = ""
 	btn.SubButtons = nil
 }
 
@@ -114,6 +133,8 @@ func (btn *Button) SetAsScanCodeWaitMsgButton(name, key string) {
 
 	btn.URL = ""
 	btn.MediaId = ""
+	btn.AppId = ""
+	btn.PagePath = ""
 	btn.SubButtons = nil
 }
 
@@ -125,6 +146,8 @@ func (btn *Button) SetAsPicSysPhotoButton(name, key string) {
 
 	btn.URL = ""
 	btn.MediaId = ""
+	btn.AppId = ""
+	btn.PagePath = ""
 	btn.SubButtons = nil
 }
 
@@ -136,6 +159,8 @@ func (btn *Button) SetAsPicPhotoOrAlbumButton(name, key string) {
 
 	btn.URL = ""
 	btn.MediaId = ""
+	btn.AppId = ""
+	btn.PagePath = ""
 	btn.SubButtons = nil
 }
 
@@ -147,6 +172,8 @@ func (btn *Button) SetAsPicWeixinButton(name, key string) {
 
 	btn.URL = ""
 	btn.MediaId = ""
+	btn.AppId = ""
+	btn.PagePath = ""
 	btn.SubButtons = nil
 }
 
@@ -158,6 +185,8 @@ func (btn *Button) SetAsLocationSelectButton(name, key string) {
 
 	btn.URL = ""
 	btn.MediaId = ""
+	btn.AppId = ""
+	btn.PagePath = ""
 	btn.SubButtons = nil
 }
 
@@ -169,6 +198,8 @@ func (btn *Button) SetAsMediaIdButton(name, mediaId string) {
 
 	btn.Key = ""
 	btn.URL = ""
+	btn.AppId = ""
+	btn.PagePath = ""
 	btn.SubButtons = nil
 }
 
@@ -180,5 +211,108 @@ func (btn *Button) SetAsViewLimitedButton(name, mediaId string) {
 
 	btn.Key = ""
 	btn.URL = ""
+	btn.AppId = ""
+	btn.PagePath = ""
 	btn.SubButtons = nil
 }
+
+// 设置 btn 指向的 Button 为 跳转小程序 类型按钮
+//  url: 不支持小程序的老版本客户端将打开本url
+func (btn *Button) SetAsMiniProgramButton(name, url, appId, pagePath string) {
+	btn.Type = ButtonTypeMiniProgram
+	btn.Name = name
+	btn.URL = url
+	btn.AppId = appId
+	btn.PagePath = pagePath
+
+	btn.Key = ""
+	btn.MediaId = ""
+	btn.SubButtons = nil
+}
+
+// 检查菜单是否合法, 包括按钮的个数, 二级菜单, 各个字段的长度以及已知类型的按钮必须的字段.
+//  不认识的按钮类型不做检查, 交给微信服务器检查.
+func (menu *Menu) CheckValid() (err error) {
+	n := len(menu.Buttons)
+	if n <= 0 {
+		return errors.New("一级菜单不能为空")
+	}
+	if n > MenuButtonCountLimit {
+		return fmt.Errorf("一级菜单按钮个数不能超过 %d, 现在为 %d", MenuButtonCountLimit, n)
+	}
+
+	for i := range menu.Buttons {
+		btn := &menu.Buttons[i]
+		if btn.Type != "" || len(btn.SubButtons) <= 0 {
+			if err = btn.checkValid(MenuButtonNameLenLimit); err != nil {
+				return fmt.Errorf("button[%d]: %s", i, err.Error())
+			}
+			if len(btn.SubButtons) > 0 {
+				return fmt.Errorf("button[%d]: 设置了 type 的按钮不能包含二级菜单", i)
+			}
+			continue
+		}
+
+		// 包含二级菜单的一级菜单按钮
+		if btn.Name == "" {
+			return fmt.Errorf("button[%d]: name 不能为空", i)
+		}
+		if len(btn.Name) > MenuButtonNameLenLimit {
+			return fmt.Errorf("button[%d]: name 的长度不能超过 %d 个字节, 现在为 %d", i, MenuButtonNameLenLimit, len(btn.Name))
+		}
+		if n := len(btn.SubButtons); n > SubMenuButtonCountLimit {
+			return fmt.Errorf("button[%d]: 二级菜单按钮个数不能超过 %d, 现在为 %d", i, SubMenuButtonCountLimit, n)
+		}
+		for j := range btn.SubButtons {
+			subBtn := &btn.SubButtons[j]
+			if len(subBtn.SubButtons) > 0 {
+				return fmt.Errorf("button[%d].sub_button[%d]: 二级菜单按钮不能再包含子菜单", i, j)
+			}
+			if err = subBtn.checkValid(SubMenuButtonNameLenLimit); err != nil {
+				return fmt.Errorf("button[%d].sub_button[%d]: %s", i, j, err.Error())
+			}
+		}
+	}
+	return
+}
+
+// 检查有 type 的按钮(非子菜单按钮)是否合法
+func (btn *Button) checkValid(nameLenLimit int) error {
+	if btn.Name == "" {
+		return errors.New("name 不能为空")
+	}
+	if len(btn.Name) > nameLenLimit {
+		return fmt.Errorf("name 的长度不能超过 %d 个字节, 现在为 %d", nameLenLimit, len(btn.Name))
+	}
+	if len(btn.Key) > ButtonKeyLenLimit {
+		return fmt.Errorf("key 的长度不能超过 %d 个字节, 现在为 %d", ButtonKeyLenLimit, len(btn.Key))
+	}
+	if len(btn.URL) > ButtonURLLenLimit {
+		return fmt.Errorf("url 的长度不能超过 %d 个字节, 现在为 %d", ButtonURLLenLimit, len(btn.URL))
+	}
+
+	switch btn.Type {
+	case ButtonTypeClick, ButtonTypeScanCodePush, ButtonTypeScanCodeWaitMsg,
+		ButtonTypePicSysPhoto, ButtonTypePicPhotoOrAlbum, ButtonTypePicWeixin, ButtonTypeLocationSelect:
+		if btn.Key == "" {
+			return fmt.Errorf("%s 类型的按钮 key 不能为空", btn.Type)
+		}
+	case ButtonTypeView:
+		if btn.URL == "" {
+			return errors.New("view 类型的按钮 url 不能为空")
+		}
+	case ButtonTypeMediaId, ButtonTypeViewLimited:
+		if btn.MediaId == "" {
+			return fmt.Errorf("%s 类型的按钮 media_id 不能为空", btn.Type)
+		}
+	case ButtonTypeMiniProgram:
+		if btn.URL == "" || btn.AppId == "" || btn.PagePath == "" {
+			return errors.New("miniprogram 类型的按钮 url, appid, pagepath 都不能为空")
+		}
+	case "":
+		return errors.New("type 不能为空")
+	default:
+		// 微信新增的按钮类型(比如 article_id, article_view_limited)交给微信服务器检查
+	}
+	return nil
+}
@@ -5,25 +5,29 @@
 
 package menu
 
+// 当前使用的自定义菜单的配置, 包括在微信公众平台官网通过网站功能发布的菜单和通过 api 创建的菜单.
 type MenuInfo struct {
 	Buttons []ButtonEx `json:"button,omitempty"`
 }
 
 type ButtonEx struct {
-	Type    string `json:"type,omitempty"`
-	Name    string `json:"name,omitempty"`
-	Key     string `json:"key,omitempty"`
-	URL     string `json:"url,omitempty"`
-	MediaId string `json:"media_id,omitempty"`
+	Type    string `json:"type,omitempty"`     // 菜单的类型, 见 ButtonType*, 官网设置的图文消息菜单为 news
+	Name    string `json:"name,omitempty"`     // 菜单名称
+	Key     string `json:"key,omitempty"`      // 通过 api 创建的 click 等类型菜单的 key
+	URL     string `json:"url,omitempty"`      // view 类型菜单的 url
+	MediaId string `json:"media_id,omitempty"` // 通过 api 创建的 media_id 类型菜单的 media_id
 
-	Value    string `json:"value,omitempty"`
+	AppId    string `json:"appid,omitempty"`    // miniprogram 类型菜单的小程序 appid
+	PagePath string `json:"pagepath,omitempty"` // miniprogram 类型菜单的小程序页面路径
+
+	Value    string `json:"value,omitempty"` // 官网设置的 text, img, voice, video 类型菜单的值
 	NewsInfo struct {
 		Articles []Article `json:"list,omitempty"`
-	} `json:"news_info"`
+	} `json:"news_info"` // 官网设置的 news 类型菜单的图文消息
 
 	SubButton struct {
 		Buttons []ButtonEx `json:"list,omitempty"`
-	} `json:"sub_button"`
+	} `json:"sub_button"` // 二级菜单
 }
 
 type Article struct {
@@ -0,0 +1,152 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package express
+
+import (
+	"errors"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 增加邮费模板, 返回邮费模板ID.
+func Add(clt *mp.Client, template *DeliveryTemplate) (templateId int64, err error) {
+	if template == nil {
+		err = errors.New("nil DeliveryTemplate")
+		return
+	}
+	template.Id = 0
+
+	request := struct {
+		DeliveryTemplate *DeliveryTemplate `json:"delivery_template"`
+	}{
+		DeliveryTemplate: template,
+	}
+
+	var result struct {
+		mp.Error
+		TemplateId int64 `json:"template_id"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/merchant/express/add?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	templateId = result.TemplateId
+	return
+}
+
+// 删除邮费模板.
+func Delete(clt *mp.Client, templateId int64) (err error) {
+	request := struct {
+		TemplateId int64 `json:"template_id"`
+	}{
+		TemplateId: templateId,
+	}
+
+	var result mp.Error
+
+	incompleteURL := "https://api.weixin.qq.com/merchant/express/del?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
+
+// 修改邮费模板.
+//  template.Id 不能为 0.
+func Update(clt *mp.Client, template *DeliveryTemplate) (err error) {
+	if template == nil {
+		err = errors.New("nil DeliveryTemplate")
+		return
+	}
+	if template.Id == 0 {
+		err = errors.New("zero template.Id")
+		return
+	}
+
+	templateId := template.Id
+	template.Id = 0
+	defer func() {
+		template.Id = templateId
+	}()
+
+	request := struct {
+		TemplateId       int64             `json:"template_id"`
+		DeliveryTemplate *DeliveryTemplate `json:"delivery_template"`
+	}{
+		TemplateId:       templateId,
+		DeliveryTemplate: template,
+	}
+
+	var result mp.Error
+
+	incompleteURL := "https://api.weixin.qq.com/merchant/express/update?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
+
+// 根据邮费模板ID获取邮费模板信息.
+func GetById(clt *mp.Client, templateId int64) (template *DeliveryTemplate, err error) {
+	request := struct {
+		TemplateId int64 `json:"template_id"`
+	}{
+		TemplateId: templateId,
+	}
+
+	var result struct {
+		mp.Error
+		TemplateInfo DeliveryTemplate `json:"template_info"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/merchant/express/getbyid?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	template = &result.TemplateInfo
+	return
+}
+
+// 获取所有邮费模板.
+func GetAll(clt *mp.Client) (templates []DeliveryTemplate, err error) {
+	var result struct {
+		mp.Error
+		TemplatesInfo []DeliveryTemplate `json:"templates_info"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/merchant/express/getall?access_token="
+	if err = clt.GetJSON(incompleteURL, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	templates = result.TemplatesInfo
+	return
+}
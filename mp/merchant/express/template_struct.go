@@ -0,0 +1,54 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package express
+
+const (
+	ExpressIdPingYou = 10000027 // 平邮
+	ExpressIdKuaiDi  = 10000028 // 快递
+	ExpressIdEMS     = 10000029 // EMS
+)
+
+const (
+	AssumerBuyer  = 0 // 买家承担运费
+	AssumerSeller = 1 // 卖家承担运费
+)
+
+const (
+	ValuationByItem   = 0 // 按件数计费
+	ValuationByWeight = 1 // 按重量计费
+)
+
+// 邮费模板
+type DeliveryTemplate struct {
+	Id        int64    `json:"Id,omitempty"` // 邮费模板ID, 增加邮费模板时不需要填写
+	Name      string   `json:"Name"`         // 邮费模板名称
+	Assumer   int      `json:"Assumer"`      // 支付方式, AssumerBuyer, AssumerSeller
+	Valuation int      `json:"Valuation"`    // 计费单位, ValuationByItem, ValuationByWeight
+	TopFees   []TopFee `json:"TopFee"`       // 具体运费计算
+}
+
+// 某种快递的运费计算
+type TopFee struct {
+	Type    int64          `json:"Type"`             // 快递类型ID, ExpressIdPingYou, ExpressIdKuaiDi, ExpressIdEMS
+	Normal  TopFeeNormal   `json:"Normal"`           // 默认邮费计算方法
+	Customs []TopFeeCustom `json:"Custom,omitempty"` // 指定地区邮费计算方法
+}
+
+// 默认邮费计算方法
+type TopFeeNormal struct {
+	StartStandards int `json:"StartStandards"` // 起始计费数量(比如计费单位是按件, 填2代表起始计费为2件)
+	StartFees      int `json:"StartFees"`      // 起始计费金额(单位: 分)
+	AddStandards   int `json:"AddStandards"`   // 递增计费数量
+	AddFees        int `json:"AddFees"`        // 递增计费金额(单位: 分)
+}
+
+// 指定地区邮费计算方法
+type TopFeeCustom struct {
+	TopFeeNormal
+	DestCountry  string `json:"DestCountry"`  // 指定国家
+	DestProvince string `json:"DestProvince"` // 指定省份
+	DestCity     string `json:"DestCity"`     // 指定城市
+}
@@ -0,0 +1,205 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package group
+
+import (
+	"errors"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 商品分组
+type Group struct {
+	Id          int64    `json:"group_id,omitempty"`     // 分组ID, 增加分组时不需要填写
+	Name        string   `json:"group_name"`             // 分组名称
+	ProductList []string `json:"product_list,omitempty"` // 商品ID集合
+}
+
+// 增加分组, 返回分组ID.
+func Add(clt *mp.Client, name string, productList []string) (groupId int64, err error) {
+	if name == "" {
+		err = errors.New("empty name")
+		return
+	}
+
+	var request struct {
+		GroupDetail Group `json:"group_detail"`
+	}
+	request.GroupDetail.Name = name
+	request.GroupDetail.ProductList = productList
+
+	var result struct {
+		mp.Error
+		GroupId int64 `json:"group_id"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/merchant/group/add?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	groupId = result.GroupId
+	return
+}
+
+// 删除分组.
+func Delete(clt *mp.Client, groupId int64) (err error) {
+	request := struct {
+		GroupId int64 `json:"group_id"`
+	}{
+		GroupId: groupId,
+	}
+
+	var result mp.Error
+
+	incompleteURL := "https://api.weixin.qq.com/merchant/group/del?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
+
+// 修改分组属性(分组名称).
+func ModifyProperty(clt *mp.Client, groupId int64, name string) (err error) {
+	if name == "" {
+		err = errors.New("empty name")
+		return
+	}
+
+	request := struct {
+		GroupId int64  `json:"group_id"`
+		Name    string `json:"group_name"`
+	}{
+		GroupId: groupId,
+		Name:    name,
+	}
+
+	var result mp.Error
+
+	incompleteURL := "https://api.weixin.qq.com/merchant/group/propertymod?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
+
+const (
+	ModActionDelete = 0 // 删除商品
+	ModActionAdd    = 1 // 增加商品
+)
+
+// 修改分组商品时的商品操作
+type ProductModify struct {
+	ProductId string `json:"product_id"`
+	ModAction int    `json:"mod_action"` // ModActionDelete, ModActionAdd
+}
+
+// 修改分组商品, 可以同时增加和删除分组里的商品.
+func ModifyProduct(clt *mp.Client, groupId int64, products []ProductModify) (err error) {
+	if len(products) <= 0 {
+		return
+	}
+
+	request := struct {
+		GroupId  int64           `json:"group_id"`
+		Products []ProductModify `json:"product"`
+	}{
+		GroupId:  groupId,
+		Products: products,
+	}
+
+	var result mp.Error
+
+	incompleteURL := "https://api.weixin.qq.com/merchant/group/productmod?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
+
+// 增加分组里的商品.
+func AddProduct(clt *mp.Client, groupId int64, productIds []string) (err error) {
+	return ModifyProduct(clt, groupId, productModifyList(productIds, ModActionAdd))
+}
+
+// 删除分组里的商品.
+func DeleteProduct(clt *mp.Client, groupId int64, productIds []string) (err error) {
+	return ModifyProduct(clt, groupId, productModifyList(productIds, ModActionDelete))
+}
+
+func productModifyList(productIds []string, modAction int) []ProductModify {
+	products := make([]ProductModify, len(productIds))
+	for i, id := range productIds {
+		products[i].ProductId = id
+		products[i].ModAction = modAction
+	}
+	return products
+}
+
+// 获取所有分组, 返回的 Group 不包含 ProductList.
+func GetAll(clt *mp.Client) (groups []Group, err error) {
+	var result struct {
+		mp.Error
+		Groups []Group `json:"groups_detail"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/merchant/group/getall?access_token="
+	if err = clt.GetJSON(incompleteURL, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	groups = result.Groups
+	return
+}
+
+// 根据分组ID获取分组信息.
+func GetById(clt *mp.Client, groupId int64) (group *Group, err error) {
+	request := struct {
+		GroupId int64 `json:"group_id"`
+	}{
+		GroupId: groupId,
+	}
+
+	var result struct {
+		mp.Error
+		Group Group `json:"group_detail"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/merchant/group/getbyid?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	group = &result.Group
+	return
+}
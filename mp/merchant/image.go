@@ -0,0 +1,118 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package merchant
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 上传图片, 返回图片的 URL, 用于商品, 货架等.
+//  NOTE: 微信小店的图片上传接口和素材管理的图片上传接口不一样, 直接把图片数据放在 http 请求的 body 中.
+func UploadImageFromFile(clt *mp.Client, _filepath string) (imageURL string, err error) {
+	file, err := os.Open(_filepath)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	return uploadImage(clt, filepath.Base(_filepath), file)
+}
+
+// 上传图片, 返回图片的 URL, 用于商品, 货架等.
+//  filename: 图片的文件名, 比如 test.png
+func UploadImage(clt *mp.Client, filename string, reader io.Reader) (imageURL string, err error) {
+	if filename == "" {
+		err = errors.New("empty filename")
+		return
+	}
+	if reader == nil {
+		err = errors.New("nil reader")
+		return
+	}
+
+	return uploadImage(clt, filename, reader)
+}
+
+func uploadImage(clt *mp.Client, filename string, reader io.Reader) (imageURL string, err error) {
+	var body bytes.Buffer
+	if _, err = io.Copy(&body, reader); err != nil {
+		return
+	}
+	bodyBytes := body.Bytes()
+
+	token, err := clt.Token()
+	if err != nil {
+		return
+	}
+
+	var result struct {
+		mp.Error
+		ImageURL string `json:"image_url"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/merchant/common/upload_img?filename=" + url.QueryEscape(filename) + "&access_token="
+
+	hasRetried := false
+	failoverCount := 0
+RETRY:
+	finalURL := clt.APIURL(incompleteURL) + url.QueryEscape(token)
+
+	httpResp, err := clt.HttpClient.Post(finalURL, "application/octet-stream", bytes.NewReader(bodyBytes))
+	if err != nil {
+		if clt.APIHostFailed(incompleteURL, finalURL, err, 0, &failoverCount) {
+			goto RETRY
+		}
+		return
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		clt.APIHostFailed(incompleteURL, finalURL, nil, httpResp.StatusCode, &failoverCount)
+		err = fmt.Errorf("http.Status: %s", httpResp.Status)
+		return
+	}
+
+	if err = json.NewDecoder(httpResp.Body).Decode(&result); err != nil {
+		return
+	}
+
+	switch result.ErrCode {
+	case mp.ErrCodeOK:
+		imageURL = result.ImageURL
+		return
+	case mp.ErrCodeInvalidCredential, mp.ErrCodeInvalidAccessToken, mp.ErrCodeAccessTokenExpired: // 失效(过期)重试一次
+		mp.LogInfoln("[WECHAT_RETRY] err_code:", result.ErrCode, ", err_msg:", result.ErrMsg)
+		mp.LogInfoln("[WECHAT_RETRY] current token:", token)
+
+		if !hasRetried {
+			hasRetried = true
+
+			if token, err = clt.TokenRefresh(); err != nil {
+				return
+			}
+			mp.LogInfoln("[WECHAT_RETRY] new token:", token)
+
+			result.Error = mp.Error{}
+			result.ImageURL = ""
+			goto RETRY
+		}
+		mp.LogInfoln("[WECHAT_RETRY] fallthrough, current token:", token)
+		fallthrough
+	default:
+		err = &result.Error
+		return
+	}
+}
@@ -0,0 +1,85 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package order
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 设置订单发货信息.
+//  deliveryCompany: 物流公司ID, 比如 DeliveryCompanyShunFeng; isOthers 为 true 时为物流公司名称
+//  deliveryTrackNo: 运单ID
+//  isOthers:        是否为其他物流公司(不在微信的物流公司列表里)
+func SetDelivery(clt *mp.Client, orderId, deliveryCompany, deliveryTrackNo string, isOthers bool) (err error) {
+	if orderId == "" {
+		err = errors.New("empty orderId")
+		return
+	}
+	if deliveryCompany == "" {
+		err = errors.New("empty deliveryCompany")
+		return
+	}
+	if !isOthers && !DeliveryCompany(deliveryCompany).Valid() {
+		err = fmt.Errorf("invalid deliveryCompany: %s, use DeliveryCompanyByName or set isOthers to true", deliveryCompany)
+		return
+	}
+	if deliveryTrackNo == "" {
+		err = errors.New("empty deliveryTrackNo")
+		return
+	}
+
+	request := struct {
+		OrderId         string `json:"order_id"`
+		DeliveryCompany string `json:"delivery_company"`
+		DeliveryTrackNo string `json:"delivery_track_no"`
+		NeedDelivery    int    `json:"need_delivery"`
+		IsOthers        int    `json:"is_others"`
+	}{
+		OrderId:         orderId,
+		DeliveryCompany: deliveryCompany,
+		DeliveryTrackNo: deliveryTrackNo,
+		NeedDelivery:    1,
+	}
+	if isOthers {
+		request.IsOthers = 1
+	}
+	return setDelivery(clt, &request)
+}
+
+// 设置订单为无需物流(比如虚拟商品), 订单状态变为已发货.
+func SetNoDelivery(clt *mp.Client, orderId string) (err error) {
+	if orderId == "" {
+		err = errors.New("empty orderId")
+		return
+	}
+
+	request := struct {
+		OrderId      string `json:"order_id"`
+		NeedDelivery int    `json:"need_delivery"`
+	}{
+		OrderId:      orderId,
+		NeedDelivery: 0,
+	}
+	return setDelivery(clt, &request)
+}
+
+func setDelivery(clt *mp.Client, request interface{}) (err error) {
+	var result mp.Error
+
+	incompleteURL := "https://api.weixin.qq.com/merchant/order/setdelivery?access_token="
+	if err = clt.PostJSON(incompleteURL, request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
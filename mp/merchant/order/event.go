@@ -0,0 +1,56 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package order
+
+import (
+	"net/http"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+const (
+	EventTypeMerchantOrder = "merchant_order" // 订单付款通知
+)
+
+// 订单付款通知
+type MerchantOrderEvent struct {
+	XMLName struct{} `xml:"xml" json:"-"`
+	mp.MessageHeader
+
+	Event       string      `xml:"Event"       json:"Event"`       // 事件类型, merchant_order
+	OrderId     string      `xml:"OrderId"     json:"OrderId"`     // 订单ID
+	OrderStatus OrderStatus `xml:"OrderStatus" json:"OrderStatus"` // 订单状态
+	ProductId   string      `xml:"ProductId"   json:"ProductId"`   // 商品ID
+	SKUInfo     string      `xml:"SkuInfo"     json:"SkuInfo"`     // SKU信息
+}
+
+func GetMerchantOrderEvent(msg *mp.MixedMessage) *MerchantOrderEvent {
+	return &MerchantOrderEvent{
+		MessageHeader: msg.MessageHeader,
+		Event:         msg.Event,
+		OrderId:       msg.OrderId,
+		OrderStatus:   OrderStatus(msg.OrderStatus),
+		ProductId:     msg.ProductId,
+		SKUInfo:       msg.SKUInfo,
+	}
+}
+
+var _ mp.MessageHandler = MerchantOrderEventHandlerFunc(nil)
+
+// 订单付款通知的处理函数, 实现了 mp.MessageHandler 接口.
+type MerchantOrderEventHandlerFunc func(http.ResponseWriter, *mp.Request, *MerchantOrderEvent)
+
+func (fn MerchantOrderEventHandlerFunc) ServeMessage(w http.ResponseWriter, r *mp.Request) {
+	fn(w, r, GetMerchantOrderEvent(r.MixedMsg))
+}
+
+// 在 mux 上注册订单付款通知的处理函数, 比如收到付款通知后发货.
+func HandleMerchantOrderEvent(mux *mp.MessageServeMux, handler func(http.ResponseWriter, *mp.Request, *MerchantOrderEvent)) {
+	if handler == nil {
+		panic("nil handler")
+	}
+	mux.EventHandle(EventTypeMerchantOrder, MerchantOrderEventHandlerFunc(handler))
+}
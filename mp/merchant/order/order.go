@@ -0,0 +1,166 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package order
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 根据订单ID获取订单详情.
+func GetById(clt *mp.Client, orderId string) (order *Order, err error) {
+	if orderId == "" {
+		err = errors.New("empty orderId")
+		return
+	}
+
+	request := struct {
+		OrderId string `json:"order_id"`
+	}{
+		OrderId: orderId,
+	}
+
+	var result struct {
+		mp.Error
+		Order Order `json:"order"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/merchant/order/getbyid?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	order = &result.Order
+	return
+}
+
+// 根据订单状态/创建时间获取订单详情.
+//  status:    订单状态, OrderStatusAll 表示全部状态
+//  beginTime: 订单创建时间起始时间(unixtime), 0 表示不限制
+//  endTime:   订单创建时间终止时间(unixtime), 0 表示不限制
+func GetByFilter(clt *mp.Client, status OrderStatus, beginTime, endTime int64) (orders []Order, err error) {
+	if status != OrderStatusAll && !status.Valid() {
+		err = fmt.Errorf("invalid order status: %d", status)
+		return
+	}
+	if beginTime < 0 || endTime < 0 || (endTime > 0 && beginTime > endTime) {
+		err = fmt.Errorf("invalid time range: [%d, %d]", beginTime, endTime)
+		return
+	}
+
+	request := struct {
+		Status    OrderStatus `json:"status,omitempty"`
+		BeginTime int64       `json:"begintime,omitempty"`
+		EndTime   int64       `json:"endtime,omitempty"`
+	}{
+		Status:    status,
+		BeginTime: beginTime,
+		EndTime:   endTime,
+	}
+
+	var result struct {
+		mp.Error
+		OrderList []Order `json:"order_list"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/merchant/order/getbyfilter?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	orders = result.OrderList
+	return
+}
+
+// Iterate 按时间窗口分批获取订单时每个窗口的大小, 单位秒.
+const IterateWindow = 24 * 60 * 60
+
+// 遍历 fn 返回 ErrStopIterate 可以提前结束遍历, Iterate 返回 nil.
+var ErrStopIterate = errors.New("stop iterate")
+
+// 遍历 [beginTime, endTime) 时间段内创建的订单.
+//  Iterate 按 IterateWindow 把时间段切分为多个窗口, 每个窗口调用一次 GetByFilter,
+//  这样订单很多的时候不需要把所有订单一次性加载到内存.
+//
+//  status:    订单状态, OrderStatusAll 表示全部状态
+//  beginTime: 订单创建时间起始时间(unixtime)
+//  endTime:   订单创建时间终止时间(unixtime)
+//  fn:        对每个订单调用, 返回 ErrStopIterate 结束遍历(Iterate 返回 nil), 返回其他 error 结束遍历并且 Iterate 返回该 error
+func Iterate(clt *mp.Client, status OrderStatus, beginTime, endTime int64, fn func(o *Order) error) (err error) {
+	if fn == nil {
+		return errors.New("nil fn")
+	}
+	if beginTime <= 0 || endTime <= 0 {
+		return errors.New("beginTime and endTime must be positive")
+	}
+
+	for windowBegin := beginTime; windowBegin < endTime; windowBegin += IterateWindow {
+		windowEnd := windowBegin + IterateWindow
+		if windowEnd > endTime {
+			windowEnd = endTime
+		}
+
+		// begintime, endtime 按闭区间处理, endtime 减一避免相邻窗口的订单重复
+		orders, err := GetByFilter(clt, status, windowBegin, windowEnd-1)
+		if err != nil {
+			return err
+		}
+		for i := range orders {
+			if err = fn(&orders[i]); err != nil {
+				if err == ErrStopIterate {
+					return nil
+				}
+				return err
+			}
+		}
+	}
+	return
+}
+
+// 获取某个买家在 [beginTime, endTime) 时间段内创建的订单.
+//  微信没有按买家查询订单的接口, 这里是先按创建时间获取订单再按 BuyerOpenId 过滤;
+//  beginTime, endTime 都大于 0 时通过 Iterate 分批获取, 否则调用一次 GetByFilter(0 表示不限制).
+//
+//  openId:    买家的 openid
+//  beginTime: 订单创建时间起始时间(unixtime)
+//  endTime:   订单创建时间终止时间(unixtime)
+func GetByBuyer(clt *mp.Client, openId string, beginTime, endTime int64) (orders []Order, err error) {
+	if openId == "" {
+		err = errors.New("empty openId")
+		return
+	}
+
+	if beginTime > 0 && endTime > 0 {
+		err = Iterate(clt, OrderStatusAll, beginTime, endTime, func(o *Order) error {
+			if o.BuyerOpenId == openId {
+				orders = append(orders, *o)
+			}
+			return nil
+		})
+		return
+	}
+
+	allOrders, err := GetByFilter(clt, OrderStatusAll, beginTime, endTime)
+	if err != nil {
+		return
+	}
+	for i := range allOrders {
+		if allOrders[i].BuyerOpenId == openId {
+			orders = append(orders, allOrders[i])
+		}
+	}
+	return
+}
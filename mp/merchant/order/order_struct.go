@@ -0,0 +1,150 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package order
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 订单状态
+type OrderStatus int
+
+const (
+	OrderStatusAll       OrderStatus = 0 // 全部状态, 只用于查询
+	OrderStatusToSend    OrderStatus = 2 // 待发货
+	OrderStatusSent      OrderStatus = 3 // 已发货
+	OrderStatusCompleted OrderStatus = 5 // 已完成
+	OrderStatusRights    OrderStatus = 8 // 维权中
+)
+
+// 是否为有效的订单状态, OrderStatusAll 不是有效的订单状态.
+func (status OrderStatus) Valid() bool {
+	switch status {
+	case OrderStatusToSend, OrderStatusSent, OrderStatusCompleted, OrderStatusRights:
+		return true
+	default:
+		return false
+	}
+}
+
+func (status OrderStatus) String() string {
+	switch status {
+	case OrderStatusAll:
+		return "全部"
+	case OrderStatusToSend:
+		return "待发货"
+	case OrderStatusSent:
+		return "已发货"
+	case OrderStatusCompleted:
+		return "已完成"
+	case OrderStatusRights:
+		return "维权中"
+	default:
+		return "未知(" + strconv.Itoa(int(status)) + ")"
+	}
+}
+
+// 物流公司ID
+type DeliveryCompany string
+
+const (
+	DeliveryCompanyEMS        DeliveryCompany = "Fsearch_code"  // 邮政EMS
+	DeliveryCompanyShenTong   DeliveryCompany = "002shentong"   // 申通快递
+	DeliveryCompanyZhongTong  DeliveryCompany = "066zhongtong"  // 中通速递
+	DeliveryCompanyYuanTong   DeliveryCompany = "056yuantong"   // 圆通速递
+	DeliveryCompanyTianTian   DeliveryCompany = "042tiantian"   // 天天快递
+	DeliveryCompanyShunFeng   DeliveryCompany = "003shunfeng"   // 顺丰速运
+	DeliveryCompanyYunDa      DeliveryCompany = "059Yunda"      // 韵达快运
+	DeliveryCompanyZhaiJiSong DeliveryCompany = "064zhaijisong" // 宅急送
+	DeliveryCompanyHuiTong    DeliveryCompany = "020huitong"    // 汇通快运
+	DeliveryCompanyYiXun      DeliveryCompany = "zj001yixun"    // 易迅快递
+)
+
+// 物流公司名称 ==> 物流公司ID
+var deliveryCompanies = map[string]DeliveryCompany{
+	"邮政EMS": DeliveryCompanyEMS,
+	"申通快递":  DeliveryCompanyShenTong,
+	"中通速递":  DeliveryCompanyZhongTong,
+	"圆通速递":  DeliveryCompanyYuanTong,
+	"天天快递":  DeliveryCompanyTianTian,
+	"顺丰速运":  DeliveryCompanyShunFeng,
+	"韵达快运":  DeliveryCompanyYunDa,
+	"宅急送":   DeliveryCompanyZhaiJiSong,
+	"汇通快运":  DeliveryCompanyHuiTong,
+	"易迅快递":  DeliveryCompanyYiXun,
+}
+
+// 根据物流公司名称查找物流公司ID, 比如 "顺丰速运" ==> DeliveryCompanyShunFeng.
+func DeliveryCompanyByName(name string) (company DeliveryCompany, ok bool) {
+	company, ok = deliveryCompanies[name]
+	return
+}
+
+// 是否为微信物流公司列表里的物流公司ID.
+func (company DeliveryCompany) Valid() bool {
+	for _, v := range deliveryCompanies {
+		if v == company {
+			return true
+		}
+	}
+	return false
+}
+
+// 物流公司名称, 不在列表里返回空字符串.
+func (company DeliveryCompany) Name() string {
+	for name, v := range deliveryCompanies {
+		if v == company {
+			return name
+		}
+	}
+	return ""
+}
+
+// 订单详情
+type Order struct {
+	OrderId           string      `json:"order_id"`            // 订单ID
+	OrderStatus       OrderStatus `json:"order_status"`        // 订单状态
+	OrderTotalPrice   int         `json:"order_total_price"`   // 订单总价格(单位: 分)
+	OrderCreateTime   int64       `json:"order_create_time"`   // 订单创建时间
+	OrderExpressPrice int         `json:"order_express_price"` // 订单运费价格(单位: 分)
+
+	BuyerOpenId string `json:"buyer_openid"` // 买家微信OPENID
+	BuyerNick   string `json:"buyer_nick"`   // 买家微信昵称
+
+	ReceiverName     string `json:"receiver_name"`     // 收货人姓名
+	ReceiverProvince string `json:"receiver_province"` // 收货地址省份
+	ReceiverCity     string `json:"receiver_city"`     // 收货地址城市
+	ReceiverZone     string `json:"receiver_zone"`     // 收货地址区/县
+	ReceiverAddress  string `json:"receiver_address"`  // 收货详细地址
+	ReceiverMobile   string `json:"receiver_mobile"`   // 收货人移动电话
+	ReceiverPhone    string `json:"receiver_phone"`    // 收货人固定电话
+
+	ProductId    string `json:"product_id"`    // 商品ID
+	ProductName  string `json:"product_name"`  // 商品名称
+	ProductPrice int    `json:"product_price"` // 商品价格(单位: 分)
+	ProductSku   string `json:"product_sku"`   // 商品SKU
+	ProductCount int    `json:"product_count"` // 商品个数
+	ProductImage string `json:"product_img"`   // 商品图片
+
+	DeliveryId      string `json:"delivery_id"`      // 运单ID
+	DeliveryCompany string `json:"delivery_company"` // 物流公司编码
+	TransId         string `json:"trans_id"`         // 交易ID
+
+	// 微信服务器返回的, 但是上面没有对应字段的数据, 没有则为 nil.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (order *Order) UnmarshalJSON(data []byte) (err error) {
+	type order_ Order // 没有 UnmarshalJSON 方法, 防止递归
+	if err = json.Unmarshal(data, (*order_)(order)); err != nil {
+		return
+	}
+	order.Extra, err = mp.UnknownJSONFields(data, order)
+	return
+}
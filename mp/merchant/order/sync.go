@@ -0,0 +1,239 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package order
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 订单的本地存储, 用于 Syncer.
+//  NOTE: Store 的实现必须是并发安全的.
+type Store interface {
+	// 获取订单, 不存在返回 nil, nil.
+	Get(orderId string) (order *Order, err error)
+
+	// 保存(新增或者覆盖)订单.
+	Put(order *Order) error
+
+	// 获取上一次同步的时间(unixtime), 没有同步过返回 0.
+	LastSyncTime() (int64, error)
+
+	// 保存这一次同步的时间(unixtime).
+	SetLastSyncTime(t int64) error
+}
+
+const (
+	OrderCreated = "created" // 新的订单
+	OrderChanged = "changed" // 订单状态, 物流信息等发生了变化
+)
+
+// 同步订单时发现的订单变化.
+type ChangeEvent struct {
+	Type     string // OrderCreated, OrderChanged
+	Order    *Order // 最新的订单
+	OldOrder *Order // 之前保存的订单, Type == OrderCreated 时为 nil
+}
+
+// 默认的回溯时间, 7天.
+//  getbyfilter 接口只能按订单的创建时间过滤, 所以每次同步都要重新获取最近创建的订单才能发现它们的变化,
+//  创建时间早于 "上次同步时间 - 回溯时间" 的订单认为不会再变化.
+const DefaultSyncLookback = 7 * 24 * 60 * 60
+
+// 订单增量同步.
+//  每次 Sync 只获取 [上次同步时间 - Lookback, 现在) 时间段内创建的订单, 和 Store 里的订单比较,
+//  新的订单和发生了变化的订单保存到 Store 并回调 OnChange.
+type Syncer struct {
+	clt   *mp.Client
+	store Store
+
+	OnChange func(event *ChangeEvent) // 可以为 nil
+	Lookback int64                    // 回溯时间, 单位秒, <= 0 时使用 DefaultSyncLookback
+
+	// 第一次同步(Store 里没有同步时间)时从这个时间(unixtime)开始获取订单, <= 0 时从现在往前 Lookback 开始.
+	InitialTime int64
+
+	mutex sync.Mutex // 同一时刻只能有一个 Sync
+}
+
+// 创建一个新的 Syncer.
+func NewSyncer(clt *mp.Client, store Store, onChange func(event *ChangeEvent)) *Syncer {
+	if clt == nil {
+		panic("nil mp.Client")
+	}
+	if store == nil {
+		panic("nil Store")
+	}
+	return &Syncer{
+		clt:      clt,
+		store:    store,
+		OnChange: onChange,
+	}
+}
+
+// 同步一次, 返回新增和变化的订单数.
+func (syncer *Syncer) Sync() (created, changed int, err error) {
+	syncer.mutex.Lock()
+	defer syncer.mutex.Unlock()
+
+	lookback := syncer.Lookback
+	if lookback <= 0 {
+		lookback = DefaultSyncLookback
+	}
+
+	now := time.Now().Unix()
+	lastSyncTime, err := syncer.store.LastSyncTime()
+	if err != nil {
+		return
+	}
+
+	var beginTime int64
+	switch {
+	case lastSyncTime > 0:
+		beginTime = lastSyncTime - lookback
+	case syncer.InitialTime > 0:
+		beginTime = syncer.InitialTime
+	default:
+		beginTime = now - lookback
+	}
+	if beginTime <= 0 {
+		beginTime = 1
+	}
+
+	err = Iterate(syncer.clt, OrderStatusAll, beginTime, now+1, func(o *Order) error {
+		old, err := syncer.store.Get(o.OrderId)
+		if err != nil {
+			return err
+		}
+
+		var event *ChangeEvent
+		switch {
+		case old == nil:
+			event = &ChangeEvent{Type: OrderCreated, Order: o}
+			created++
+		case orderChanged(old, o):
+			event = &ChangeEvent{Type: OrderChanged, Order: o, OldOrder: old}
+			changed++
+		default:
+			return nil
+		}
+
+		if err = syncer.store.Put(o); err != nil {
+			return err
+		}
+		if syncer.OnChange != nil {
+			syncer.OnChange(event)
+		}
+		return nil
+	})
+	if err != nil {
+		return
+	}
+
+	err = syncer.store.SetLastSyncTime(now)
+	return
+}
+
+// 每隔 interval 同步一次, 直到 stop 被关闭. 同步出错只记录日志, 下一次继续同步.
+func (syncer *Syncer) Run(interval time.Duration, stop <-chan struct{}) {
+	if interval <= 0 {
+		panic("interval must be positive")
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if created, changed, err := syncer.Sync(); err != nil {
+			mp.LogInfoln("[WECHAT_ORDER_SYNC] sync failed:", err)
+		} else if created > 0 || changed > 0 {
+			mp.LogInfoln("[WECHAT_ORDER_SYNC] created:", created, ", changed:", changed)
+		}
+
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// 订单是否发生了变化, 只比较会变化的字段.
+func orderChanged(old, order *Order) bool {
+	return old.OrderStatus != order.OrderStatus ||
+		old.DeliveryId != order.DeliveryId ||
+		old.DeliveryCompany != order.DeliveryCompany ||
+		old.TransId != order.TransId
+}
+
+var _ Store = (*MemoryStore)(nil)
+
+// 基于内存的 Store, 用于单进程环境, 进程重启后会重新全量同步.
+type MemoryStore struct {
+	mutex        sync.RWMutex
+	orders       map[string]Order
+	lastSyncTime int64
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		orders: make(map[string]Order),
+	}
+}
+
+func (store *MemoryStore) Get(orderId string) (order *Order, err error) {
+	store.mutex.RLock()
+	o, ok := store.orders[orderId]
+	store.mutex.RUnlock()
+
+	if !ok {
+		return
+	}
+	order = &o
+	return
+}
+
+func (store *MemoryStore) Put(order *Order) error {
+	if order == nil {
+		return errors.New("nil order")
+	}
+
+	store.mutex.Lock()
+	store.orders[order.OrderId] = *order
+	store.mutex.Unlock()
+	return nil
+}
+
+func (store *MemoryStore) LastSyncTime() (int64, error) {
+	store.mutex.RLock()
+	t := store.lastSyncTime
+	store.mutex.RUnlock()
+	return t, nil
+}
+
+func (store *MemoryStore) SetLastSyncTime(t int64) error {
+	store.mutex.Lock()
+	store.lastSyncTime = t
+	store.mutex.Unlock()
+	return nil
+}
+
+// 遍历 Store 里的所有订单, fn 返回 false 结束遍历.
+//  NOTE: fn 里不能调用 store 的 Put, SetLastSyncTime 方法.
+func (store *MemoryStore) Range(fn func(order *Order) bool) {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	for _, o := range store.orders {
+		o := o
+		if !fn(&o) {
+			return
+		}
+	}
+}
@@ -0,0 +1,112 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package order
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"strconv"
+)
+
+var _ Store = (*SQLiteStore)(nil)
+
+// 基于 SQLite 的 Store, 用于进程重启后不需要全量同步的场景.
+//  SQLiteStore 只依赖 database/sql, 需要调用者自己导入 SQLite 的驱动, 比如:
+//      import _ "github.com/mattn/go-sqlite3"
+//      db, err := sql.Open("sqlite3", "orders.db")
+//      store, err := order.NewSQLiteStore(db, "merchant_order")
+//
+//  订单以 JSON 的格式保存在表 table 里, 同步时间保存在表 table_sync 里.
+type SQLiteStore struct {
+	db        *sql.DB
+	table     string
+	syncTable string
+}
+
+var sqlTableNameRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// 创建一个新的 SQLiteStore, 如果表不存在则创建.
+//  table 是保存订单的表名, 只能包含字母, 数字和下划线.
+func NewSQLiteStore(db *sql.DB, table string) (store *SQLiteStore, err error) {
+	if db == nil {
+		return nil, errors.New("nil sql.DB")
+	}
+	if !sqlTableNameRegexp.MatchString(table) {
+		return nil, errors.New("invalid table name: " + strconv.Quote(table))
+	}
+
+	store = &SQLiteStore{
+		db:        db,
+		table:     table,
+		syncTable: table + "_sync",
+	}
+
+	if _, err = db.Exec(`CREATE TABLE IF NOT EXISTS ` + store.table + ` (
+		order_id          TEXT PRIMARY KEY,
+		order_status      INTEGER NOT NULL,
+		order_create_time INTEGER NOT NULL,
+		buyer_openid      TEXT NOT NULL,
+		data              TEXT NOT NULL
+	)`); err != nil {
+		return nil, err
+	}
+	if _, err = db.Exec(`CREATE TABLE IF NOT EXISTS ` + store.syncTable + ` (
+		id             INTEGER PRIMARY KEY CHECK (id = 1),
+		last_sync_time INTEGER NOT NULL
+	)`); err != nil {
+		return nil, err
+	}
+	return
+}
+
+func (store *SQLiteStore) Get(orderId string) (order *Order, err error) {
+	var data string
+	err = store.db.QueryRow(`SELECT data FROM `+store.table+` WHERE order_id = ?`, orderId).Scan(&data)
+	switch err {
+	case nil:
+	case sql.ErrNoRows:
+		return nil, nil
+	default:
+		return
+	}
+
+	var o Order
+	if err = json.Unmarshal([]byte(data), &o); err != nil {
+		return
+	}
+	order = &o
+	return
+}
+
+func (store *SQLiteStore) Put(order *Order) error {
+	if order == nil {
+		return errors.New("nil order")
+	}
+
+	data, err := json.Marshal(order)
+	if err != nil {
+		return err
+	}
+	_, err = store.db.Exec(`INSERT OR REPLACE INTO `+store.table+
+		` (order_id, order_status, order_create_time, buyer_openid, data) VALUES (?, ?, ?, ?, ?)`,
+		order.OrderId, int(order.OrderStatus), order.OrderCreateTime, order.BuyerOpenId, string(data))
+	return err
+}
+
+func (store *SQLiteStore) LastSyncTime() (t int64, err error) {
+	err = store.db.QueryRow(`SELECT last_sync_time FROM ` + store.syncTable + ` WHERE id = 1`).Scan(&t)
+	if err == sql.ErrNoRows {
+		err = nil
+	}
+	return
+}
+
+func (store *SQLiteStore) SetLastSyncTime(t int64) error {
+	_, err := store.db.Exec(`INSERT OR REPLACE INTO `+store.syncTable+` (id, last_sync_time) VALUES (1, ?)`, t)
+	return err
+}
@@ -0,0 +1,114 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package product
+
+import (
+	"github.com/chanxuehong/wechat/mp"
+)
+
+const RootCategoryId = 1 // 根分类的id, 用于获取一级分类
+
+type Category struct {
+	Id   string `json:"id"`   // 子分类ID
+	Name string `json:"name"` // 子分类名称
+}
+
+// 获取指定分类的所有子分类.
+//  categoryId: 大分类ID(根节点分类id为1)
+func GetSubCategory(clt *mp.Client, categoryId int64) (categories []Category, err error) {
+	request := struct {
+		CategoryId int64 `json:"cate_id"`
+	}{
+		CategoryId: categoryId,
+	}
+
+	var result struct {
+		mp.Error
+		Categories []Category `json:"cate_list"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/merchant/category/getsub?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	categories = result.Categories
+	return
+}
+
+type SkuTableItem struct {
+	Id     string `json:"id"`   // sku属性ID
+	Name   string `json:"name"` // sku属性名称
+	Values []struct {
+		Id   string `json:"id"`   // sku值ID
+		Name string `json:"name"` // sku值名称
+	} `json:"value_list"` // sku属性值列表
+}
+
+// 获取指定子分类的所有SKU.
+func GetSku(clt *mp.Client, categoryId int64) (skuTable []SkuTableItem, err error) {
+	request := struct {
+		CategoryId int64 `json:"cate_id"`
+	}{
+		CategoryId: categoryId,
+	}
+
+	var result struct {
+		mp.Error
+		SkuTable []SkuTableItem `json:"sku_table"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/merchant/category/getsku?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	skuTable = result.SkuTable
+	return
+}
+
+type CategoryProperty struct {
+	Id     string `json:"id"`   // 属性id
+	Name   string `json:"name"` // 属性名称
+	Values []struct {
+		Id   string `json:"id"`   // 属性值id
+		Name string `json:"name"` // 属性值名称
+	} `json:"property_value"` // 属性值
+}
+
+// 获取指定分类的所有属性.
+func GetProperty(clt *mp.Client, categoryId int64) (properties []CategoryProperty, err error) {
+	request := struct {
+		CategoryId int64 `json:"cate_id"`
+	}{
+		CategoryId: categoryId,
+	}
+
+	var result struct {
+		mp.Error
+		Properties []CategoryProperty `json:"properties"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/merchant/category/getproperty?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	properties = result.Properties
+	return
+}
@@ -0,0 +1,190 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package product
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/chanxuehong/wechat/mp"
+	"github.com/chanxuehong/wechat/mp/merchant"
+)
+
+// 微信图片服务器的域名, 这些域名下的图片不需要迁移
+var wechatImageHosts = []string{
+	"mmbiz.qpic.cn",
+	"mmbiz.qlogo.cn",
+}
+
+// 把商品里的外部图片迁移到微信的图片服务器.
+//  商品的图片(MainImage, Images, Detail 里的图片, Sku.IconURL)必须是通过 merchant.UploadImage 上传得到的 URL,
+//  迁移商品目录的时候, ImageMigrator 下载这些外部图片, 上传到微信, 然后把商品里的 URL 替换为微信返回的 URL.
+//
+//  同一个 ImageMigrator 会记住已经迁移过的 URL, 同一张图片只上传一次.
+type ImageMigrator struct {
+	clt        *mp.Client
+	httpClient *http.Client // 用于下载外部图片
+
+	mutex    sync.Mutex
+	migrated map[string]string // 外部 URL ==> 微信图片 URL
+}
+
+// 创建一个新的 ImageMigrator.
+//  httpClient 用于下载外部图片, 如果 httpClient == nil 则默认使用 http.DefaultClient.
+func NewImageMigrator(clt *mp.Client, httpClient *http.Client) *ImageMigrator {
+	if clt == nil {
+		panic("nil mp.Client")
+	}
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &ImageMigrator{
+		clt:        clt,
+		httpClient: httpClient,
+		migrated:   make(map[string]string),
+	}
+}
+
+// 迁移一张图片, 返回微信图片服务器上的 URL.
+//  如果 imageURL 为空, 不是 http(s) URL 或者已经是微信图片服务器的 URL, 则原样返回.
+//  失败时 newURL 也是原来的 imageURL.
+func (migrator *ImageMigrator) MigrateImage(imageURL string) (newURL string, err error) {
+	if !needMigrate(imageURL) {
+		return imageURL, nil
+	}
+	defer func() {
+		if err != nil {
+			newURL = imageURL
+		}
+	}()
+
+	migrator.mutex.Lock()
+	newURL, ok := migrator.migrated[imageURL]
+	migrator.mutex.Unlock()
+	if ok {
+		return
+	}
+
+	httpResp, err := migrator.httpClient.Get(imageURL)
+	if err != nil {
+		return
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		err = fmt.Errorf("download %s failed, http.Status: %s", imageURL, httpResp.Status)
+		return
+	}
+
+	if newURL, err = merchant.UploadImage(migrator.clt, imageFilename(imageURL), httpResp.Body); err != nil {
+		return
+	}
+
+	migrator.mutex.Lock()
+	migrator.migrated[imageURL] = newURL
+	migrator.mutex.Unlock()
+	return
+}
+
+// 迁移商品里所有的外部图片, 并且把 product 里的 URL 替换为微信图片服务器上的 URL.
+//  出错时 product 里已经迁移的图片 URL 已经被替换, 没有迁移的保持不变, 可以修复问题后再次调用.
+func (migrator *ImageMigrator) MigrateProduct(product *Product) (err error) {
+	base := &product.ProductBase
+
+	if err = migrator.migrateImageInPlace(&base.MainImage); err != nil {
+		return
+	}
+	for i := range base.Images {
+		if err = migrator.migrateImageInPlace(&base.Images[i]); err != nil {
+			return
+		}
+	}
+	for i := range base.Detail {
+		if err = migrator.migrateImageInPlace(&base.Detail[i].Image); err != nil {
+			return
+		}
+	}
+	for i := range product.SkuList {
+		if err = migrator.migrateImageInPlace(&product.SkuList[i].IconURL); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// 迁移 *imageURL 指向的图片, 成功才替换 *imageURL, 失败保持不变.
+func (migrator *ImageMigrator) migrateImageInPlace(imageURL *string) (err error) {
+	newURL, err := migrator.MigrateImage(*imageURL)
+	if err != nil {
+		return
+	}
+	*imageURL = newURL
+	return
+}
+
+// 某个商品迁移图片失败.
+type MigrateError struct {
+	Index int   // 商品在 products 里的下标
+	Err   error // 失败的原因
+}
+
+// MigrateProducts 返回的错误, 包含所有迁移失败的商品.
+type MigrateErrors []MigrateError
+
+func (errs MigrateErrors) Error() string {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "%d product(s) failed to migrate images", len(errs))
+	for _, e := range errs {
+		fmt.Fprintf(&buf, "; products[%d]: %s", e.Index, e.Err)
+	}
+	return buf.String()
+}
+
+// 批量迁移商品里的外部图片, 某个商品失败了会继续迁移下一个商品.
+//  如果有商品失败, 返回的 err 是 MigrateErrors.
+func (migrator *ImageMigrator) MigrateProducts(products []Product) (err error) {
+	var errs MigrateErrors
+	for i := range products {
+		if err := migrator.MigrateProduct(&products[i]); err != nil {
+			errs = append(errs, MigrateError{Index: i, Err: err})
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return
+}
+
+func needMigrate(imageURL string) bool {
+	if !strings.HasPrefix(imageURL, "http://") && !strings.HasPrefix(imageURL, "https://") {
+		return false
+	}
+	u, err := url.Parse(imageURL)
+	if err != nil {
+		return false
+	}
+	for _, host := range wechatImageHosts {
+		if u.Host == host {
+			return false
+		}
+	}
+	return true
+}
+
+// 从 URL 的路径里取文件名, 取不到的话使用 image.jpg
+func imageFilename(imageURL string) string {
+	if u, err := url.Parse(imageURL); err == nil {
+		if name := path.Base(u.Path); name != "" && name != "." && name != "/" {
+			return name
+		}
+	}
+	return "image.jpg"
+}
@@ -0,0 +1,180 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package product
+
+import (
+	"errors"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 增加商品, 返回商品ID.
+func Create(clt *mp.Client, product *Product) (productId string, err error) {
+	if product == nil {
+		err = errors.New("nil Product")
+		return
+	}
+	request := *product // 不修改调用者的 product
+	request.ProductId = ""
+	request.Status = 0
+
+	var result struct {
+		mp.Error
+		ProductId string `json:"product_id"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/merchant/create?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	productId = result.ProductId
+	return
+}
+
+// 删除商品.
+func Delete(clt *mp.Client, productId string) (err error) {
+	request := struct {
+		ProductId string `json:"product_id"`
+	}{
+		ProductId: productId,
+	}
+
+	var result mp.Error
+
+	incompleteURL := "https://api.weixin.qq.com/merchant/del?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
+
+// 修改商品.
+//  NOTE:
+//  1. product.ProductId 不能为空;
+//  2. 从未上架的商品所有信息均可修改, 否则商品的名称(name), 商品的分类(category), 商品的属性(property)这三个字段不可修改;
+//  3. 上架中的商品不能修改, 需要先下架.
+func Update(clt *mp.Client, product *Product) (err error) {
+	if product == nil {
+		err = errors.New("nil Product")
+		return
+	}
+	if product.ProductId == "" {
+		err = errors.New("empty ProductId")
+		return
+	}
+	request := *product // 不修改调用者的 product
+	request.Status = 0
+
+	var result mp.Error
+
+	incompleteURL := "https://api.weixin.qq.com/merchant/update?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
+
+// 查询商品.
+func Get(clt *mp.Client, productId string) (product *Product, err error) {
+	request := struct {
+		ProductId string `json:"product_id"`
+	}{
+		ProductId: productId,
+	}
+
+	var result struct {
+		mp.Error
+		Product Product `json:"product_info"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/merchant/get?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	product = &result.Product
+	return
+}
+
+// 获取指定状态的所有商品.
+//  status: ProductStatusAll, ProductStatusOnShelf, ProductStatusOffShelf
+func GetByStatus(clt *mp.Client, status int) (products []Product, err error) {
+	switch status {
+	case ProductStatusAll, ProductStatusOnShelf, ProductStatusOffShelf:
+	default:
+		err = errors.New("invalid status")
+		return
+	}
+
+	request := struct {
+		Status int `json:"status"`
+	}{
+		Status: status,
+	}
+
+	var result struct {
+		mp.Error
+		Products []Product `json:"products_info"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/merchant/getbystatus?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	products = result.Products
+	return
+}
+
+// 商品上下架.
+//  onShelf: true 表示上架, false 表示下架
+func ModifyStatus(clt *mp.Client, productId string, onShelf bool) (err error) {
+	request := struct {
+		ProductId string `json:"product_id"`
+		Status    int    `json:"status"`
+	}{
+		ProductId: productId,
+	}
+	if onShelf {
+		request.Status = 1
+	}
+
+	var result mp.Error
+
+	incompleteURL := "https://api.weixin.qq.com/merchant/modproductstatus?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
@@ -0,0 +1,95 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package product
+
+const (
+	ProductStatusAll      = 0 // 全部
+	ProductStatusOnShelf  = 1 // 上架
+	ProductStatusOffShelf = 2 // 下架
+)
+
+// 商品
+type Product struct {
+	ProductId    string        `json:"product_id,omitempty"`    // 商品ID, 创建商品时不需要填写
+	Status       int           `json:"status,omitempty"`        // 商品状态, 只在获取商品时有效, 1: 上架, 2: 下架
+	ProductBase  ProductBase   `json:"product_base"`            // 基本属性
+	SkuList      []Sku         `json:"sku_list,omitempty"`      // sku信息列表(可为多个), 每个sku信息串即为一个确定的商品, 比如白色的37码的鞋子
+	AttrExt      *AttrExt      `json:"attrext,omitempty"`       // 商品其他属性
+	DeliveryInfo *DeliveryInfo `json:"delivery_info,omitempty"` // 运费信息
+}
+
+// 商品的基本属性(attrBase)
+type ProductBase struct {
+	CategoryId []string        `json:"category_id,omitempty"` // 商品分类id, 商品分类列表请通过 GetSubCategory 获得
+	Property   []Property      `json:"property,omitempty"`    // 商品属性列表, 属性列表请通过 GetProperty 获得
+	Name       string          `json:"name"`                  // 商品名称
+	SkuInfo    []SkuInfo       `json:"sku_info,omitempty"`    // 商品sku定义, SKU列表请通过 GetSku 获得
+	MainImage  string          `json:"main_img"`              // 商品主图(图片需调用图片上传接口获得图片URL填写至此, 否则无法添加商品. 图片分辨率推荐尺寸为640×600)
+	Images     []string        `json:"img,omitempty"`         // 商品图片列表(图片需调用图片上传接口获得图片URL填写至此, 否则无法添加商品. 图片分辨率推荐尺寸为640×600)
+	Detail     []ProductDetail `json:"detail,omitempty"`      // 商品详情列表, 显示在客户端的商品详情页内
+	BuyLimit   int             `json:"buy_limit,omitempty"`   // 用户商品限购数量
+	DetailHTML string          `json:"detail_html,omitempty"` // 商品详情页面的html, 只在获取商品时返回
+}
+
+// 商品属性
+type Property struct {
+	Id    string `json:"id"`  // 属性id
+	Value string `json:"vid"` // 属性值id
+}
+
+// 商品sku定义
+type SkuInfo struct {
+	Id     string   `json:"id"`  // sku属性(SKU列表中id, 支持自定义SKU, 格式为"$xxx", xxx即为显示在客户端中的字符串)
+	Values []string `json:"vid"` // sku值(SKU列表中vid, 如需自定义SKU, 格式为"$xxx", xxx即为显示在客户端中的字符串)
+}
+
+// 商品详情, Text 和 Image 同时只有一个有效
+type ProductDetail struct {
+	Text  string `json:"text,omitempty"` // 文字描述
+	Image string `json:"img,omitempty"`  // 图片(图片需调用图片上传接口获得图片URL填写至此, 否则无法添加商品)
+}
+
+// sku信息
+type Sku struct {
+	Id            string `json:"sku_id"`                 // sku信息, 参照上述sku_table的定义; 格式: "id1:vid1;id2:vid2", 规则: id_info的组合个数必须与sku_table个数一致(若商品无sku信息, 即商品为统一规格, 则此处赋值为空字符串即可)
+	Price         int    `json:"price"`                  // sku微信价, 单位: 分
+	IconURL       string `json:"icon_url,omitempty"`     // sku iconurl(图片需调用图片上传接口获得图片URL)
+	ProductCode   string `json:"product_code,omitempty"` // 商家商品编码
+	OriginalPrice int    `json:"ori_price"`              // sku原价, 单位: 分
+	Quantity      int    `json:"quantity"`               // sku库存
+}
+
+// 商品其他属性
+type AttrExt struct {
+	Location struct {
+		Country  string `json:"country"`  // 国家(详见《地区列表》说明)
+		Province string `json:"province"` // 省份(详见《地区列表》说明)
+		City     string `json:"city"`     // 城市(详见《地区列表》说明)
+		Address  string `json:"address"`  // 地址
+	} `json:"location"` // 商品所在地地址
+
+	IsPostFree       int `json:"isPostFree"`       // 是否包邮(0-否, 1-是), 如果包邮 DeliveryInfo 字段可省略
+	IsHasReceipt     int `json:"isHasReceipt"`     // 是否提供发票(0-否, 1-是)
+	IsUnderGuaranty  int `json:"isUnderGuaranty"`  // 是否保修(0-否, 1-是)
+	IsSupportReplace int `json:"isSupportReplace"` // 是否支持退换货(0-否, 1-是)
+}
+
+const (
+	DeliveryTypeExpress  = 0 // 使用 DeliveryInfo.Express
+	DeliveryTypeTemplate = 1 // 使用 DeliveryInfo.TemplateId 指定的邮费模板
+)
+
+// 运费信息
+type DeliveryInfo struct {
+	DeliveryType int       `json:"delivery_type"`         // 运费类型(0-使用下面express字段的默认模板, 1-使用template_id代表的邮费模板)
+	TemplateId   int64     `json:"template_id,omitempty"` // 邮费模板ID
+	Express      []Express `json:"express,omitempty"`
+}
+
+type Express struct {
+	Id    int64 `json:"id"`    // 快递ID, 10000027: 平邮, 10000028: 快递, 10000029: EMS
+	Price int   `json:"price"` // 运费, 单位: 分
+}
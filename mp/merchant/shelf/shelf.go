@@ -0,0 +1,155 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package shelf
+
+import (
+	"errors"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 增加货架, 返回货架ID.
+func Add(clt *mp.Client, shelf *Shelf) (shelfId int64, err error) {
+	if shelf == nil {
+		err = errors.New("nil Shelf")
+		return
+	}
+	request := *shelf // 不修改调用者的 shelf
+	request.Id = 0
+
+	var result struct {
+		mp.Error
+		ShelfId int64 `json:"shelf_id"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/merchant/shelf/add?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	shelfId = result.ShelfId
+	return
+}
+
+// 删除货架.
+func Delete(clt *mp.Client, shelfId int64) (err error) {
+	request := struct {
+		ShelfId int64 `json:"shelf_id"`
+	}{
+		ShelfId: shelfId,
+	}
+
+	var result mp.Error
+
+	incompleteURL := "https://api.weixin.qq.com/merchant/shelf/del?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
+
+// 修改货架.
+//  shelf.Id 不能为 0.
+func Modify(clt *mp.Client, shelf *Shelf) (err error) {
+	if shelf == nil {
+		err = errors.New("nil Shelf")
+		return
+	}
+	if shelf.Id == 0 {
+		err = errors.New("zero shelf.Id")
+		return
+	}
+
+	var result mp.Error
+
+	incompleteURL := "https://api.weixin.qq.com/merchant/shelf/mod?access_token="
+	if err = clt.PostJSON(incompleteURL, shelf, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
+
+// 获取货架时返回的货架信息, 货架控件在 shelf_info 字段里.
+type shelfInfo struct {
+	Id     int64  `json:"shelf_id"`
+	Banner string `json:"shelf_banner"`
+	Name   string `json:"shelf_name"`
+	Info   struct {
+		Modules []Module `json:"module_infos"`
+	} `json:"shelf_info"`
+}
+
+func (info *shelfInfo) toShelf() (shelf Shelf) {
+	shelf.Id = info.Id
+	shelf.Banner = info.Banner
+	shelf.Name = info.Name
+	shelf.Data.Modules = info.Info.Modules
+	return
+}
+
+// 获取所有货架.
+func GetAll(clt *mp.Client) (shelves []Shelf, err error) {
+	var result struct {
+		mp.Error
+		Shelves []shelfInfo `json:"shelves"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/merchant/shelf/getall?access_token="
+	if err = clt.GetJSON(incompleteURL, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	shelves = make([]Shelf, len(result.Shelves))
+	for i := range result.Shelves {
+		shelves[i] = result.Shelves[i].toShelf()
+	}
+	return
+}
+
+// 根据货架ID获取货架信息.
+func GetById(clt *mp.Client, shelfId int64) (shelf *Shelf, err error) {
+	request := struct {
+		ShelfId int64 `json:"shelf_id"`
+	}{
+		ShelfId: shelfId,
+	}
+
+	var result struct {
+		mp.Error
+		shelfInfo
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/merchant/shelf/getbyid?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	s := result.shelfInfo.toShelf()
+	shelf = &s
+	return
+}
@@ -0,0 +1,110 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package shelf
+
+// 货架
+type Shelf struct {
+	Id     int64  `json:"shelf_id,omitempty"` // 货架ID, 增加货架时不需要填写
+	Banner string `json:"shelf_banner"`       // 货架招牌图片Url(图片需调用图片上传接口获得图片Url填写至此, 否则添加货架失败, 建议尺寸为640*120, 仅控件1-4有banner, 控件5没有banner)
+	Name   string `json:"shelf_name"`         // 货架名称
+	Data   struct {
+		Modules []Module `json:"module_infos"`
+	} `json:"shelf_data"` // 货架信息(数据说明详见《货架控件说明》)
+}
+
+// 货架控件, 通过 NewModule1, NewModule2, NewModule3, NewModule4, NewModule5 创建
+type Module struct {
+	EId             int         `json:"eid"`                      // 控件ID
+	GroupInfo       *GroupInfo  `json:"group_info,omitempty"`     // 控件1, 控件3 的分组信息
+	GroupInfos      *GroupInfos `json:"group_infos,omitempty"`    // 控件2, 控件4, 控件5 的分组信息
+	ImageBackground string      `json:"img_background,omitempty"` // 控件5 的背景图片
+}
+
+type GroupInfo struct {
+	GroupId int64 `json:"group_id"`
+	Filter  *struct {
+		Count int `json:"count"` // 该控件展示商品个数
+	} `json:"filter,omitempty"` // 控件1 的分组商品过滤条件
+	Image string `json:"img,omitempty"` // 控件3 的分组照片
+}
+
+type GroupInfos struct {
+	Groups []Group `json:"groups"`
+}
+
+type Group struct {
+	GroupId int64  `json:"group_id"`
+	Image   string `json:"img,omitempty"` // 控件4 的分组照片
+}
+
+// 控件1, 由一个分组组成, 显示该分组下的 count 个商品, 建议 count 为偶数.
+func NewModule1(groupId int64, count int) *Module {
+	module := &Module{
+		EId: 1,
+		GroupInfo: &GroupInfo{
+			GroupId: groupId,
+		},
+	}
+	module.GroupInfo.Filter = &struct {
+		Count int `json:"count"`
+	}{
+		Count: count,
+	}
+	return module
+}
+
+// 控件2, 由多个分组组成(最多有4个分组), 显示分组的名称.
+func NewModule2(groupIds []int64) *Module {
+	groups := make([]Group, len(groupIds))
+	for i, id := range groupIds {
+		groups[i].GroupId = id
+	}
+	return &Module{
+		EId: 2,
+		GroupInfos: &GroupInfos{
+			Groups: groups,
+		},
+	}
+}
+
+// 控件3, 由一个分组组成, 显示分组的图片.
+//  image: 分组照片(图片需调用图片上传接口获得图片Url填写至此, 否则添加货架失败, 建议分辨率600*208)
+func NewModule3(groupId int64, image string) *Module {
+	return &Module{
+		EId: 3,
+		GroupInfo: &GroupInfo{
+			GroupId: groupId,
+			Image:   image,
+		},
+	}
+}
+
+// 控件4, 由多个分组组成(最多3个分组), 显示分组的图片.
+//  Group.Image: 分组照片(图片需调用图片上传接口获得图片Url填写至此, 否则添加货架失败, 3个分组建议分辨率分别为: 350*480, 244*208, 244*208)
+func NewModule4(groups []Group) *Module {
+	return &Module{
+		EId: 4,
+		GroupInfos: &GroupInfos{
+			Groups: groups,
+		},
+	}
+}
+
+// 控件5, 由多个分组组成(最多5个分组), 显示分组的名称和一张背景图片.
+//  imageBackground: 背景图片(图片需调用图片上传接口获得图片Url填写至此, 否则添加货架失败, 建议分辨率640*1008)
+func NewModule5(groupIds []int64, imageBackground string) *Module {
+	groups := make([]Group, len(groupIds))
+	for i, id := range groupIds {
+		groups[i].GroupId = id
+	}
+	return &Module{
+		EId: 5,
+		GroupInfos: &GroupInfos{
+			Groups: groups,
+		},
+		ImageBackground: imageBackground,
+	}
+}
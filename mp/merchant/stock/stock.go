@@ -0,0 +1,80 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package stock
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 增加库存.
+//  productId: 商品ID
+//  skuInfo:   sku信息, 格式"id1:vid1;id2:vid2", 如商品为统一规格, 则此处赋值为空字符串即可
+//  quantity:  增加的库存数量
+func Add(clt *mp.Client, productId, skuInfo string, quantity int) (err error) {
+	return modify(clt, "https://api.weixin.qq.com/merchant/stock/add?access_token=", productId, skuInfo, quantity)
+}
+
+// 减少库存.
+//  productId: 商品ID
+//  skuInfo:   sku信息, 格式"id1:vid1;id2:vid2", 如商品为统一规格, 则此处赋值为空字符串即可
+//  quantity:  减少的库存数量
+func Reduce(clt *mp.Client, productId, skuInfo string, quantity int) (err error) {
+	return modify(clt, "https://api.weixin.qq.com/merchant/stock/reduce?access_token=", productId, skuInfo, quantity)
+}
+
+func modify(clt *mp.Client, incompleteURL, productId, skuInfo string, quantity int) (err error) {
+	if productId == "" {
+		err = errors.New("empty productId")
+		return
+	}
+	if quantity <= 0 {
+		err = fmt.Errorf("quantity must be greater than 0, now is %d", quantity)
+		return
+	}
+	if err = CheckSkuInfo(skuInfo); err != nil {
+		return
+	}
+
+	request := struct {
+		ProductId string `json:"product_id"`
+		SkuInfo   string `json:"sku_info"`
+		Quantity  int    `json:"quantity"`
+	}{
+		ProductId: productId,
+		SkuInfo:   skuInfo,
+		Quantity:  quantity,
+	}
+
+	var result mp.Error
+
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
+
+// 检查 sku 信息的格式, 合法的格式为 "" 或者 "id1:vid1;id2:vid2".
+func CheckSkuInfo(skuInfo string) error {
+	if skuInfo == "" {
+		return nil
+	}
+	for _, pair := range strings.Split(skuInfo, ";") {
+		i := strings.IndexByte(pair, ':')
+		if i <= 0 || i == len(pair)-1 || strings.IndexByte(pair[i+1:], ':') >= 0 {
+			return fmt.Errorf("invalid sku_info: %q, the format should be \"id1:vid1;id2:vid2\"", skuInfo)
+		}
+	}
+	return nil
+}
@@ -69,6 +69,14 @@ func (clt *Client) SendNews(msg *News) (err error) {
 	return clt.send(msg)
 }
 
+// 发送客服消息, 图文(永久图文素材).
+func (clt *Client) SendMpNews(msg *MpNews) (err error) {
+	if msg == nil {
+		return errors.New("msg == nil")
+	}
+	return clt.send(msg)
+}
+
 // 发送客服消息, 卡卷.
 func (clt *Client) SendWxCard(msg *WxCard) (err error) {
 	if msg == nil {
@@ -78,16 +86,6 @@ func (clt *Client) SendWxCard(msg *WxCard) (err error) {
 }
 
 func (clt *Client) send(msg interface{}) (err error) {
-	var result mp.Error
-
 	incompleteURL := "https://api.weixin.qq.com/cgi-bin/message/custom/send?access_token="
-	if err = ((*mp.Client)(clt)).PostJSON(incompleteURL, msg, &result); err != nil {
-		return
-	}
-
-	if result.ErrCode != mp.ErrCodeOK {
-		err = &result
-		return
-	}
-	return
+	return ((*mp.Client)(clt)).PostJSONErrorOnly(incompleteURL, msg)
 }
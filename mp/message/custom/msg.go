@@ -17,6 +17,7 @@ const (
 	MsgTypeVideo  = "video"  // 视频消息
 	MsgTypeMusic  = "music"  // 音乐消息
 	MsgTypeNews   = "news"   // 图文消息
+	MsgTypeMpNews = "mpnews" // 图文消息, 发送的是永久图文素材
 	MsgTypeWxCard = "wxcard" // 卡卷消息
 )
 
@@ -253,6 +254,36 @@ func NewNews(toUser string, articles []Article, kfAccount string) (news *News) {
 	return
 }
 
+// 图文消息, 点击跳转到图文消息页面, 图文消息条数限制在8条以内
+type MpNews struct {
+	MessageHeader
+
+	MpNews struct {
+		MediaId string `json:"media_id"` // 永久图文素材的 media_id
+	} `json:"mpnews"`
+
+	*CustomService `json:"customservice,omitempty"`
+}
+
+// 新建图文消息(永久图文素材).
+//  如果不指定客服则 kfAccount 留空.
+func NewMpNews(toUser, mediaId, kfAccount string) (news *MpNews) {
+	news = &MpNews{
+		MessageHeader: MessageHeader{
+			ToUser:  toUser,
+			MsgType: MsgTypeMpNews,
+		},
+	}
+	news.MpNews.MediaId = mediaId
+
+	if kfAccount != "" {
+		news.CustomService = &CustomService{
+			KfAccount: kfAccount,
+		}
+	}
+	return
+}
+
 // 卡券消息, 特别注意客服消息接口投放卡券仅支持非自定义Code码的卡券
 type WxCard struct {
 	MessageHeader
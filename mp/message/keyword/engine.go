@@ -0,0 +1,190 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package keyword
+
+import (
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/chanxuehong/wechat/mp"
+	"github.com/chanxuehong/wechat/mp/message/response"
+)
+
+// 根据收到的消息构造回复消息, 比如 *response.Text, 返回 nil 表示回复空串.
+type ReplyFunc func(msg *mp.MixedMessage) (reply interface{})
+
+// 回复文本消息
+func TextReply(content string) ReplyFunc {
+	return func(msg *mp.MixedMessage) interface{} {
+		return response.ReplyText(msg, content)
+	}
+}
+
+// 回复图片消息
+func ImageReply(mediaId string) ReplyFunc {
+	return func(msg *mp.MixedMessage) interface{} {
+		return response.ReplyImage(msg, mediaId)
+	}
+}
+
+// 回复语音消息
+func VoiceReply(mediaId string) ReplyFunc {
+	return func(msg *mp.MixedMessage) interface{} {
+		return response.ReplyVoice(msg, mediaId)
+	}
+}
+
+// 回复视频消息
+func VideoReply(mediaId, title, description string) ReplyFunc {
+	return func(msg *mp.MixedMessage) interface{} {
+		return response.ReplyVideo(msg, mediaId, title, description)
+	}
+}
+
+// 回复图文消息, 文章个数超过 response.NewsArticleCountLimit 时 panic.
+func NewsReply(articles ...response.Article) ReplyFunc {
+	if len(articles) > response.NewsArticleCountLimit {
+		panic("too many articles")
+	}
+	return func(msg *mp.MixedMessage) interface{} {
+		news, err := response.ReplyNews(msg, articles...)
+		if err != nil {
+			return nil
+		}
+		return news
+	}
+}
+
+const (
+	matchExact = iota
+	matchPrefix
+	matchRegexp
+)
+
+type rule struct {
+	matchType int
+	keyword   string
+	regexp    *regexp.Regexp
+	priority  int
+	order     int // 注册的顺序, priority 相同时先注册的先匹配
+	reply     ReplyFunc
+}
+
+func (r *rule) match(content string) bool {
+	switch r.matchType {
+	case matchExact:
+		return content == r.keyword
+	case matchPrefix:
+		return strings.HasPrefix(content, r.keyword)
+	default:
+		return r.regexp.MatchString(content)
+	}
+}
+
+var _ mp.MessageHandler = (*Engine)(nil)
+
+// 关键字自动回复的规则引擎, 同时也是一个 mp.MessageHandler 的实现.
+//  只处理文本消息, 按 priority 从大到小匹配规则(priority 相同时先注册的先匹配), 匹配的规则用
+//  ReplyFunc 构造回复消息; 没有匹配的规则或者不是文本消息则交给 fallback 处理.
+//  匹配前会去掉消息内容首尾的空白.
+//
+//  engine := keyword.NewEngine(mux)
+//  engine.Exact("帮助", 0, keyword.TextReply("回复 1 查询订单"))
+//  engine.MustRegexp(`^\d{11}$`, 0, queryByMobile)
+//  srv := mp.NewDefaultServer(oriId, token, appId, aesKey, engine)
+type Engine struct {
+	rwmutex  sync.RWMutex
+	rules    []*rule
+	order    int
+	fallback mp.MessageHandler
+}
+
+// 创建一个新的 Engine, fallback 可以为 nil, 表示回复空串.
+func NewEngine(fallback mp.MessageHandler) *Engine {
+	return &Engine{
+		fallback: fallback,
+	}
+}
+
+// 注册完全匹配的规则.
+func (engine *Engine) Exact(keyword string, priority int, reply ReplyFunc) {
+	engine.add(&rule{matchType: matchExact, keyword: keyword, priority: priority, reply: reply})
+}
+
+// 注册前缀匹配的规则.
+func (engine *Engine) Prefix(keyword string, priority int, reply ReplyFunc) {
+	engine.add(&rule{matchType: matchPrefix, keyword: keyword, priority: priority, reply: reply})
+}
+
+// 注册正则表达式匹配的规则.
+func (engine *Engine) Regexp(pattern string, priority int, reply ReplyFunc) (err error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return
+	}
+	engine.add(&rule{matchType: matchRegexp, regexp: re, priority: priority, reply: reply})
+	return
+}
+
+// 和 Regexp 一样, 但是 pattern 无效时 panic.
+func (engine *Engine) MustRegexp(pattern string, priority int, reply ReplyFunc) {
+	if err := engine.Regexp(pattern, priority, reply); err != nil {
+		panic(err)
+	}
+}
+
+func (engine *Engine) add(r *rule) {
+	if r.reply == nil {
+		panic("nil ReplyFunc")
+	}
+
+	engine.rwmutex.Lock()
+	defer engine.rwmutex.Unlock()
+
+	r.order = engine.order
+	engine.order++
+	engine.rules = append(engine.rules, r)
+	sort.SliceStable(engine.rules, func(i, j int) bool {
+		if engine.rules[i].priority != engine.rules[j].priority {
+			return engine.rules[i].priority > engine.rules[j].priority
+		}
+		return engine.rules[i].order < engine.rules[j].order
+	})
+}
+
+// 查找 content 匹配的规则, 没有匹配的规则返回 nil.
+func (engine *Engine) Match(content string) ReplyFunc {
+	content = strings.TrimSpace(content)
+
+	engine.rwmutex.RLock()
+	defer engine.rwmutex.RUnlock()
+
+	for _, r := range engine.rules {
+		if r.match(content) {
+			return r.reply
+		}
+	}
+	return nil
+}
+
+func (engine *Engine) ServeMessage(w http.ResponseWriter, r *mp.Request) {
+	if msg := r.MixedMsg; msg.MsgType == response.MsgTypeText {
+		if reply := engine.Match(msg.Content); reply != nil {
+			if replyMsg := reply(msg); replyMsg != nil {
+				if err := mp.WriteResponse(w, r, replyMsg); err != nil {
+					mp.LogInfoln("[WECHAT_KEYWORD] write response error:", err)
+				}
+			}
+			return
+		}
+	}
+	if engine.fallback != nil {
+		engine.fallback.ServeMessage(w, r)
+	}
+}
@@ -6,6 +6,8 @@
 package mass
 
 import (
+	"net/http"
+
 	"github.com/chanxuehong/wechat/mp"
 )
 
@@ -43,10 +45,38 @@ type MassSendJobFinishEvent struct {
 	FilterCount int `xml:"FilterCount" json:"FilterCount"`
 	SentCount   int `xml:"SentCount"   json:"SentCount"`  // 发送成功的粉丝数
 	ErrorCount  int `xml:"ErrorCount"  json:"ErrorCount"` // 发送失败的粉丝数
+
+	// 群发图文消息时的原创校验结果
+	CopyrightCheckResult CopyrightCheckResult `xml:"CopyrightCheckResult" json:"CopyrightCheckResult"`
+}
+
+const (
+	CopyrightCheckStateSuccess = 1 // 未被判为转载, 可以群发
+	CopyrightCheckStateReprint = 2 // 被判为转载, 可以群发
+	CopyrightCheckStateFailed  = 3 // 被判为转载, 不能群发
+)
+
+// 原创校验结果, 和 github.com/chanxuehong/wechat/mp.CopyrightCheckResult 一样, 同步修改
+type CopyrightCheckResult struct {
+	Count      int                        `xml:"Count"                     json:"Count"`                // 图文消息的文章数
+	ResultList []CopyrightCheckResultItem `xml:"ResultList>item,omitempty" json:"ResultList,omitempty"` // 各篇文章的校验结果
+	CheckState int                        `xml:"CheckState"                json:"CheckState"`           // 整体校验结果, CopyrightCheckState*
+}
+
+// 单篇文章的原创校验结果, 和 github.com/chanxuehong/wechat/mp.CopyrightCheckResultItem 一样, 同步修改
+type CopyrightCheckResultItem struct {
+	ArticleIdx            int    `xml:"ArticleIdx"            json:"ArticleIdx"`            // 群发文章的序号, 从1开始
+	UserDeclareState      int    `xml:"UserDeclareState"      json:"UserDeclareState"`      // 用户声明文章的状态
+	AuditState            int    `xml:"AuditState"            json:"AuditState"`            // 系统校验的状态
+	OriginalArticleURL    string `xml:"OriginalArticleUrl"    json:"OriginalArticleUrl"`    // 相似原创文的url
+	OriginalArticleType   int    `xml:"OriginalArticleType"   json:"OriginalArticleType"`   // 相似原创文的类型
+	CanReprint            int    `xml:"CanReprint"            json:"CanReprint"`            // 是否能转载
+	NeedReplaceContent    int    `xml:"NeedReplaceContent"    json:"NeedReplaceContent"`    // 是否需要替换成原创文内容
+	NeedShowReprintSource int    `xml:"NeedShowReprintSource" json:"NeedShowReprintSource"` // 是否需要注明转载来源
 }
 
 func GetMassSendJobFinishEvent(msg *mp.MixedMessage) *MassSendJobFinishEvent {
-	return &MassSendJobFinishEvent{
+	event := &MassSendJobFinishEvent{
 		MessageHeader: msg.MessageHeader,
 		Event:         msg.Event,
 		MsgId:         msg.MsgID, // NOTE
@@ -56,4 +86,36 @@ func GetMassSendJobFinishEvent(msg *mp.MixedMessage) *MassSendJobFinishEvent {
 		SentCount:     msg.SentCount,
 		ErrorCount:    msg.ErrorCount,
 	}
+
+	event.CopyrightCheckResult.Count = msg.CopyrightCheckResult.Count
+	event.CopyrightCheckResult.CheckState = msg.CopyrightCheckResult.CheckState
+	if n := len(msg.CopyrightCheckResult.ResultList); n > 0 {
+		event.CopyrightCheckResult.ResultList = make([]CopyrightCheckResultItem, n)
+		for i, item := range msg.CopyrightCheckResult.ResultList {
+			event.CopyrightCheckResult.ResultList[i] = CopyrightCheckResultItem(item)
+		}
+	}
+	return event
+}
+
+// 群发是否成功, 成功时也有可能少量用户接收失败, 见 ErrorCount.
+func (event *MassSendJobFinishEvent) Success() bool {
+	return event.Status == "send success"
+}
+
+var _ mp.MessageHandler = MassSendJobFinishEventHandlerFunc(nil)
+
+// 群发结果事件的处理函数, 实现了 mp.MessageHandler 接口.
+type MassSendJobFinishEventHandlerFunc func(http.ResponseWriter, *mp.Request, *MassSendJobFinishEvent)
+
+func (fn MassSendJobFinishEventHandlerFunc) ServeMessage(w http.ResponseWriter, r *mp.Request) {
+	fn(w, r, GetMassSendJobFinishEvent(r.MixedMsg))
+}
+
+// 在 mux 上注册群发结果事件的处理函数.
+func HandleMassSendJobFinishEvent(mux *mp.MessageServeMux, handler func(http.ResponseWriter, *mp.Request, *MassSendJobFinishEvent)) {
+	if handler == nil {
+		panic("nil handler")
+	}
+	mux.EventHandle(EventTypeMassSendJobFinish, MassSendJobFinishEventHandlerFunc(handler))
 }
@@ -69,6 +69,31 @@ func (clt *Client) MassToGroup(msg interface{}) (rslt *MassResult, err error) {
 	return
 }
 
+// 群发消息给指定标签的用户
+func (clt *Client) MassToTag(msg interface{}) (rslt *MassResult, err error) {
+	if msg == nil {
+		err = errors.New("nil msg")
+		return
+	}
+
+	var result struct {
+		mp.Error
+		MassResult
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/message/mass/sendall?access_token="
+	if err = ((*mp.Client)(clt)).PostJSON(incompleteURL, msg, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	rslt = &result.MassResult
+	return
+}
+
 // 群发消息给指定用户列表
 func (clt *Client) MassToUsers(msg interface{}) (rslt *MassResult, err error) {
 	if msg == nil {
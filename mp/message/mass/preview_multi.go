@@ -0,0 +1,76 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mass
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// PreviewMulti 默认的并发数
+const DefaultPreviewConcurrency = 5
+
+// 给某个测试用户发送预览消息失败.
+type PreviewError struct {
+	ToUser string // 测试用户的 openid(或者微信号)
+	Err    error  // 失败的原因
+}
+
+// PreviewMulti 返回的错误, 包含所有发送失败的测试用户.
+type PreviewErrors []PreviewError
+
+func (errs PreviewErrors) Error() string {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "preview failed for %d user(s)", len(errs))
+	for _, e := range errs {
+		fmt.Fprintf(&buf, "; %s: %s", e.ToUser, e.Err)
+	}
+	return buf.String()
+}
+
+// 给多个测试用户发送预览消息.
+//  预览接口每次只能发给一个用户, PreviewMulti 对每个用户调用 newMsg 生成消息(比如 preview.NewText(touser, content)),
+//  然后并发的调用 Preview, 同一时刻最多 concurrency 个请求, concurrency <= 0 时使用 DefaultPreviewConcurrency.
+//
+//  某个用户发送失败不影响其他用户, 如果有用户发送失败, 返回的 err 是 PreviewErrors(和 tousers 的顺序一致).
+func (clt *Client) PreviewMulti(tousers []string, newMsg func(touser string) interface{}, concurrency int) (err error) {
+	if newMsg == nil {
+		return errors.New("nil newMsg")
+	}
+	if concurrency <= 0 {
+		concurrency = DefaultPreviewConcurrency
+	}
+
+	errs := make([]error, len(tousers))
+	sem := make(chan struct{}, concurrency)
+
+	var wg sync.WaitGroup
+	for i, touser := range tousers {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, touser string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			errs[i] = clt.Preview(newMsg(touser))
+		}(i, touser)
+	}
+	wg.Wait()
+
+	var previewErrs PreviewErrors
+	for i, e := range errs {
+		if e != nil {
+			previewErrs = append(previewErrs, PreviewError{ToUser: tousers[i], Err: e})
+		}
+	}
+	if len(previewErrs) > 0 {
+		return previewErrs
+	}
+	return
+}
@@ -0,0 +1,76 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mass
+
+import (
+	"fmt"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 群发速度的级别
+const (
+	SpeedLevel0 = 0 // 80w/分钟
+	SpeedLevel1 = 1 // 60w/分钟
+	SpeedLevel2 = 2 // 45w/分钟
+	SpeedLevel3 = 3 // 30w/分钟
+	SpeedLevel4 = 4 // 10w/分钟
+)
+
+type MassSpeed struct {
+	Speed     int `json:"speed"`     // 群发速度的级别
+	RealSpeed int `json:"realspeed"` // 群发速度的真实值, 单位: 万/分钟
+}
+
+// 获取群发速度.
+func (clt *Client) GetMassSpeed() (speed *MassSpeed, err error) {
+	var request struct{}
+
+	var result struct {
+		mp.Error
+		MassSpeed
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/message/mass/speed/get?access_token="
+	if err = ((*mp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	speed = &result.MassSpeed
+	return
+}
+
+// 设置群发速度.
+//  level: 群发速度的级别, SpeedLevel0 ~ SpeedLevel4, 级别越小速度越快
+func (clt *Client) SetMassSpeed(level int) (err error) {
+	if level < SpeedLevel0 || level > SpeedLevel4 {
+		err = fmt.Errorf("invalid speed level: %d", level)
+		return
+	}
+
+	var request = struct {
+		Speed int `json:"speed"`
+	}{
+		Speed: level,
+	}
+
+	var result mp.Error
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/message/mass/speed/set?access_token="
+	if err = ((*mp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
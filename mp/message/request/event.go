@@ -7,6 +7,7 @@ package request
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/chanxuehong/wechat/mp"
@@ -63,17 +64,28 @@ type SubscribeByScanEvent struct {
 	Ticket   string `xml:"Ticket"   json:"Ticket"`   // 二维码的ticket, 可用来换取二维码图片
 }
 
+// 关注事件里带参数二维码的 EventKey 前缀
+const qrScenePrefix = "qrscene_"
+
 // 获取二维码参数
 func (event *SubscribeByScanEvent) Scene() (scene string, err error) {
-	const prefix = "qrscene_"
-	if !strings.HasPrefix(event.EventKey, prefix) {
-		err = fmt.Errorf("EventKey 应该以 %q 为前缀: %q", prefix, event.EventKey)
+	if !strings.HasPrefix(event.EventKey, qrScenePrefix) {
+		err = fmt.Errorf("EventKey 应该以 %q 为前缀: %q", qrScenePrefix, event.EventKey)
 		return
 	}
-	scene = event.EventKey[len(prefix):]
+	scene = event.EventKey[len(qrScenePrefix):]
 	return
 }
 
+// 获取二维码的 scene_id, 如果二维码的参数是 scene_str 则返回错误.
+func (event *SubscribeByScanEvent) SceneId() (sceneId uint32, err error) {
+	scene, err := event.Scene()
+	if err != nil {
+		return
+	}
+	return parseSceneId(scene)
+}
+
 func GetSubscribeByScanEvent(msg *mp.MixedMessage) *SubscribeByScanEvent {
 	return &SubscribeByScanEvent{
 		MessageHeader: msg.MessageHeader,
@@ -93,6 +105,16 @@ type ScanEvent struct {
 	Ticket   string `xml:"Ticket"   json:"Ticket"`   // 二维码的ticket, 可用来换取二维码图片
 }
 
+// 获取二维码参数
+func (event *ScanEvent) Scene() string {
+	return event.EventKey
+}
+
+// 获取二维码的 scene_id, 如果二维码的参数是 scene_str 则返回错误.
+func (event *ScanEvent) SceneId() (sceneId uint32, err error) {
+	return parseSceneId(event.EventKey)
+}
+
 func GetScanEvent(msg *mp.MixedMessage) *ScanEvent {
 	return &ScanEvent{
 		MessageHeader: msg.MessageHeader,
@@ -102,6 +124,36 @@ func GetScanEvent(msg *mp.MixedMessage) *ScanEvent {
 	}
 }
 
+// 获取扫描带参数二维码的参数(scene_id 或 scene_str), 用于统计关注渠道等.
+//  msg 为扫描带参数二维码关注的 subscribe 事件或者已关注用户扫码的 SCAN 事件时 ok == true,
+//  其他消息(包括普通关注事件) ok == false.
+func GetScene(msg *mp.MixedMessage) (scene string, ok bool) {
+	if msg.MsgType != "event" {
+		return
+	}
+	switch msg.Event {
+	case EventTypeSubscribe:
+		if !strings.HasPrefix(msg.EventKey, qrScenePrefix) {
+			return
+		}
+		return msg.EventKey[len(qrScenePrefix):], true
+	case EventTypeScan:
+		return msg.EventKey, true
+	default:
+		return
+	}
+}
+
+func parseSceneId(scene string) (sceneId uint32, err error) {
+	id, err := strconv.ParseUint(scene, 10, 32)
+	if err != nil {
+		err = fmt.Errorf("二维码参数不是 scene_id: %q", scene)
+		return
+	}
+	sceneId = uint32(id)
+	return
+}
+
 // 上报地理位置事件
 type LocationEvent struct {
 	XMLName struct{} `xml:"xml" json:"-"`
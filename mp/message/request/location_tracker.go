@@ -0,0 +1,113 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package request
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 用户最后一次上报的地理位置.
+type UserLocation struct {
+	OpenId     string  `json:"openid"`
+	Latitude   float64 `json:"latitude"`    // 地理位置纬度
+	Longitude  float64 `json:"longitude"`   // 地理位置经度
+	Precision  float64 `json:"precision"`   // 地理位置精度
+	ReportTime int64   `json:"report_time"` // 上报的时间, 即事件的 CreateTime
+}
+
+// 用户地理位置的存储接口, 可以用 redis 等实现, 用于多个进程之间共享.
+//  NOTE: LocationStore 的实现必须是并发安全的.
+type LocationStore interface {
+	// 保存用户的地理位置, 如果已经保存的地理位置比 location 新(ReportTime 更大)则忽略 location.
+	Put(location *UserLocation) (err error)
+
+	// 获取用户最后一次上报的地理位置, 没有找到返回 nil, nil.
+	Get(openId string) (location *UserLocation, err error)
+}
+
+var _ mp.MessageHandler = (*LocationTracker)(nil)
+
+// 跟踪用户上报地理位置(LOCATION)事件的 MessageHandler 中间件.
+//  收到 LOCATION 事件时把用户的地理位置保存到 LocationStore, 然后交给 handler 处理;
+//  其他消息(事件)直接交给 handler 处理.
+type LocationTracker struct {
+	handler mp.MessageHandler
+	store   LocationStore
+}
+
+// 创建一个新的 LocationTracker.
+//  如果 handler == nil 则 LOCATION 事件保存后回复空串, 其他消息(事件)也回复空串.
+//  如果 store == nil 则使用 NewMemoryLocationStore(), 只能用于单进程环境.
+func NewLocationTracker(handler mp.MessageHandler, store LocationStore) *LocationTracker {
+	if store == nil {
+		store = NewMemoryLocationStore()
+	}
+	return &LocationTracker{
+		handler: handler,
+		store:   store,
+	}
+}
+
+// 获取用户最后一次上报的地理位置, 没有找到返回 nil, nil.
+func (tracker *LocationTracker) Location(openId string) (location *UserLocation, err error) {
+	return tracker.store.Get(openId)
+}
+
+func (tracker *LocationTracker) ServeMessage(w http.ResponseWriter, r *mp.Request) {
+	if msg := r.MixedMsg; msg.MsgType == "event" && msg.Event == EventTypeLocation {
+		location := &UserLocation{
+			OpenId:     msg.FromUserName,
+			Latitude:   msg.Latitude,
+			Longitude:  msg.Longitude,
+			Precision:  msg.Precision,
+			ReportTime: msg.CreateTime,
+		}
+		if err := tracker.store.Put(location); err != nil {
+			mp.LogInfoln("[WECHAT_LOCATION] store error:", err)
+		}
+	}
+	if tracker.handler != nil {
+		tracker.handler.ServeMessage(w, r)
+	}
+}
+
+var _ LocationStore = (*MemoryLocationStore)(nil)
+
+// LocationStore 的内存实现, 只能用于单进程环境.
+type MemoryLocationStore struct {
+	rwmutex   sync.RWMutex
+	locations map[string]UserLocation
+}
+
+func NewMemoryLocationStore() *MemoryLocationStore {
+	return &MemoryLocationStore{
+		locations: make(map[string]UserLocation),
+	}
+}
+
+func (store *MemoryLocationStore) Put(location *UserLocation) (err error) {
+	store.rwmutex.Lock()
+	defer store.rwmutex.Unlock()
+
+	if old, ok := store.locations[location.OpenId]; ok && old.ReportTime > location.ReportTime {
+		return
+	}
+	store.locations[location.OpenId] = *location
+	return
+}
+
+func (store *MemoryLocationStore) Get(openId string) (location *UserLocation, err error) {
+	store.rwmutex.RLock()
+	defer store.rwmutex.RUnlock()
+
+	if v, ok := store.locations[openId]; ok {
+		location = &v
+	}
+	return
+}
@@ -158,7 +158,7 @@ type Article struct {
 }
 
 const (
-	NewsArticleCountLimit = 10 // 被动回复图文消息的文章数据最大数
+	NewsArticleCountLimit = 8 // 被动回复图文消息的文章数据最大数
 )
 
 // 图文消息
@@ -166,8 +166,8 @@ type News struct {
 	XMLName struct{} `xml:"xml" json:"-"`
 	mp.MessageHeader
 
-	ArticleCount int       `xml:"ArticleCount"            json:"ArticleCount"`       // 图文消息个数, 限制为10条以内
-	Articles     []Article `xml:"Articles>item,omitempty" json:"Articles,omitempty"` // 多条图文消息信息, 默认第一个item为大图, 注意, 如果图文数超过10, 则将会无响应
+	ArticleCount int       `xml:"ArticleCount"            json:"ArticleCount"`       // 图文消息个数, 限制为8条以内
+	Articles     []Article `xml:"Articles>item,omitempty" json:"Articles,omitempty"` // 多条图文消息信息, 默认第一个item为大图, 注意, 如果图文数超过8, 则将会无响应
 }
 
 // 检查 News 是否有效, 有效返回 nil, 否则返回错误信息
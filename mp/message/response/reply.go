@@ -0,0 +1,57 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package response
+
+import (
+	"time"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 下面的 ReplyXxx 函数根据收到的消息 msg 构造回复消息:
+// ToUserName 为 msg.FromUserName, FromUserName 为 msg.ToUserName, CreateTime 为当前时间.
+// 构造的消息直接用 mp.WriteResponse 回复即可, mp.MarshalCDATAXML 会把字符串内容用 <![CDATA[...]]> 包裹,
+// 内容里的 "]]>" 会被拆分到两个 CDATA 段里, 不需要转义.
+
+// 回复文本消息.
+func ReplyText(msg *mp.MixedMessage, content string) *Text {
+	return NewText(msg.FromUserName, msg.ToUserName, time.Now().Unix(), content)
+}
+
+// 回复图片消息.
+func ReplyImage(msg *mp.MixedMessage, mediaId string) *Image {
+	return NewImage(msg.FromUserName, msg.ToUserName, time.Now().Unix(), mediaId)
+}
+
+// 回复语音消息.
+func ReplyVoice(msg *mp.MixedMessage, mediaId string) *Voice {
+	return NewVoice(msg.FromUserName, msg.ToUserName, time.Now().Unix(), mediaId)
+}
+
+// 回复视频消息.
+func ReplyVideo(msg *mp.MixedMessage, mediaId, title, description string) *Video {
+	return NewVideo(msg.FromUserName, msg.ToUserName, time.Now().Unix(), mediaId, title, description)
+}
+
+// 回复音乐消息.
+func ReplyMusic(msg *mp.MixedMessage, thumbMediaId, musicURL, HQMusicURL, title, description string) *Music {
+	return NewMusic(msg.FromUserName, msg.ToUserName, time.Now().Unix(), thumbMediaId, musicURL, HQMusicURL, title, description)
+}
+
+// 回复图文消息, 文章个数不能超过 NewsArticleCountLimit.
+func ReplyNews(msg *mp.MixedMessage, articles ...Article) (news *News, err error) {
+	news = NewNews(msg.FromUserName, msg.ToUserName, time.Now().Unix(), articles)
+	if err = news.CheckValid(); err != nil {
+		news = nil
+		return
+	}
+	return
+}
+
+// 将消息转发到多客服, 如果不指定客服则 kfAccount 留空.
+func ReplyTransferToCustomerService(msg *mp.MixedMessage, kfAccount string) *TransferToCustomerService {
+	return NewTransferToCustomerService(msg.FromUserName, msg.ToUserName, time.Now().Unix(), kfAccount)
+}
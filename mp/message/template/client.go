@@ -47,6 +47,34 @@ func (clt *Client) SetIndustry(industryId ...int64) (err error) {
 	return
 }
 
+// 行业信息
+type Industry struct {
+	FirstClass  string `json:"first_class"`  // 主行业
+	SecondClass string `json:"second_class"` // 副行业
+}
+
+// 获取设置的行业信息.
+func (clt *Client) GetIndustry() (primaryIndustry, secondaryIndustry Industry, err error) {
+	var result struct {
+		mp.Error
+		PrimaryIndustry   Industry `json:"primary_industry"`
+		SecondaryIndustry Industry `json:"secondary_industry"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/template/get_industry?access_token="
+	if err = ((*mp.Client)(clt)).GetJSON(incompleteURL, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	primaryIndustry = result.PrimaryIndustry
+	secondaryIndustry = result.SecondaryIndustry
+	return
+}
+
 // 从行业模板库选择模板添加到账号后台, 并返回模板id.
 //  templateIdShort: 模板库中模板的编号, 有"TM**"和"OPENTMTM**"等形式.
 func (clt *Client) AddTemplate(templateIdShort string) (templateId string, err error) {
@@ -74,6 +102,62 @@ func (clt *Client) AddTemplate(templateIdShort string) (templateId string, err e
 	return
 }
 
+// 账号下的模板信息
+type Template struct {
+	TemplateId      string `json:"template_id"`      // 模板ID
+	Title           string `json:"title"`            // 模板标题
+	PrimaryIndustry string `json:"primary_industry"` // 模板所属行业的一级行业
+	DeputyIndustry  string `json:"deputy_industry"`  // 模板所属行业的二级行业
+	Content         string `json:"content"`          // 模板内容
+	Example         string `json:"example"`          // 模板示例
+}
+
+// 获取已添加至帐号下所有模板列表.
+func (clt *Client) GetAllPrivateTemplate() (templateList []Template, err error) {
+	var result struct {
+		mp.Error
+		TemplateList []Template `json:"template_list"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/template/get_all_private_template?access_token="
+	if err = ((*mp.Client)(clt)).GetJSON(incompleteURL, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	templateList = result.TemplateList
+	return
+}
+
+// 删除帐号下的某个模板.
+func (clt *Client) DeletePrivateTemplate(templateId string) (err error) {
+	if templateId == "" {
+		return errors.New("empty templateId")
+	}
+
+	var request = struct {
+		TemplateId string `json:"template_id"`
+	}{
+		TemplateId: templateId,
+	}
+
+	var result mp.Error
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/template/del_private_template?access_token="
+	if err = ((*mp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
+
 // 发送模板消息
 func (clt *Client) Send(msg *TemplateMessage) (msgid int64, err error) {
 	if msg == nil {
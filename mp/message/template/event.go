@@ -6,6 +6,8 @@
 package template
 
 import (
+	"net/http"
+
 	"github.com/chanxuehong/wechat/mp"
 )
 
@@ -37,3 +39,25 @@ func GetTemplateSendJobFinishEvent(msg *mp.MixedMessage) *TemplateSendJobFinishE
 		Status:        msg.Status,
 	}
 }
+
+// 模板消息是否送达成功.
+func (event *TemplateSendJobFinishEvent) Success() bool {
+	return event.Status == TemplateSendStatusSuccess
+}
+
+var _ mp.MessageHandler = TemplateSendJobFinishEventHandlerFunc(nil)
+
+// 模板消息发送结果事件的处理函数, 实现了 mp.MessageHandler 接口.
+type TemplateSendJobFinishEventHandlerFunc func(http.ResponseWriter, *mp.Request, *TemplateSendJobFinishEvent)
+
+func (fn TemplateSendJobFinishEventHandlerFunc) ServeMessage(w http.ResponseWriter, r *mp.Request) {
+	fn(w, r, GetTemplateSendJobFinishEvent(r.MixedMsg))
+}
+
+// 在 mux 上注册模板消息发送结果事件的处理函数.
+func HandleTemplateSendJobFinishEvent(mux *mp.MessageServeMux, handler func(http.ResponseWriter, *mp.Request, *TemplateSendJobFinishEvent)) {
+	if handler == nil {
+		panic("nil handler")
+	}
+	mux.EventHandle(EventTypeTemplateSendJobFinish, TemplateSendJobFinishEventHandlerFunc(handler))
+}
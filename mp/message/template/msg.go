@@ -10,10 +10,34 @@ import (
 )
 
 type TemplateMessage struct {
-	ToUser     string `json:"touser"`             // 必须, 接受者OpenID
-	TemplateId string `json:"template_id"`        // 必须, 模版ID
-	URL        string `json:"url,omitempty"`      // 可选, 用户点击后跳转的URL, 该URL必须处于开发者在公众平台网站中设置的域中
-	TopColor   string `json:"topcolor,omitempty"` // 可选, 整个消息的颜色, 可以不设置
+	ToUser      string       `json:"touser"`                // 必须, 接受者OpenID
+	TemplateId  string       `json:"template_id"`           // 必须, 模版ID
+	URL         string       `json:"url,omitempty"`         // 可选, 用户点击后跳转的URL, 该URL必须处于开发者在公众平台网站中设置的域中
+	MiniProgram *MiniProgram `json:"miniprogram,omitempty"` // 可选, 跳小程序所需数据, 同时设置了 URL 则优先跳转小程序
+	TopColor    string       `json:"topcolor,omitempty"`    // 可选, 整个消息的颜色, 可以不设置
 
 	RawJSONData json.RawMessage `json:"data"` // 必须, JSON 格式的 []byte, 满足特定的模板需求
 }
+
+// 模板消息跳转的小程序
+type MiniProgram struct {
+	AppId    string `json:"appid"`              // 必须, 所需跳转到的小程序appid(该小程序appid必须与发模板消息的公众号是绑定关联关系)
+	PagePath string `json:"pagepath,omitempty"` // 可选, 所需跳转到小程序的具体页面路径, 支持带参数(示例index?foo=bar)
+}
+
+// 模板消息里的一个数据项
+type DataItem struct {
+	Value string `json:"value"`           // 必须, 数据项的值
+	Color string `json:"color,omitempty"` // 可选, 数据项的颜色, 比如 #173177
+}
+
+// 用 map[数据项名称]DataItem 设置 RawJSONData.
+//  比如模板内容里有 {{first.DATA}}, 那么 data 里就要有 key 为 "first" 的 DataItem.
+func (msg *TemplateMessage) SetData(data map[string]DataItem) (err error) {
+	rawJSONData, err := json.Marshal(data)
+	if err != nil {
+		return
+	}
+	msg.RawJSONData = rawJSONData
+	return
+}
@@ -0,0 +1,98 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package template
+
+import (
+	"sync"
+	"time"
+)
+
+// 默认的发送记录保存时间, 一般几秒内就会收到 TEMPLATESENDJOBFINISH 事件.
+const DefaultTrackTTL = time.Hour
+
+// SendTracker 记录模板消息的 msgid 和发送时的上下文, 收到 TEMPLATESENDJOBFINISH 事件后
+// 可以通过事件里的 MsgId 找回发送时的上下文, 比如业务的订单号.
+//  NOTE: 只保存在内存中, 多进程环境下请自己用 msgid 关联.
+type SendTracker struct {
+	ttl time.Duration
+
+	mutex     sync.Mutex
+	records   map[int64]trackRecord
+	lastPurge time.Time
+}
+
+type trackRecord struct {
+	value    interface{}
+	expireAt time.Time
+}
+
+// 创建一个新的 SendTracker, ttl <= 0 时使用 DefaultTrackTTL.
+func NewSendTracker(ttl time.Duration) *SendTracker {
+	if ttl <= 0 {
+		ttl = DefaultTrackTTL
+	}
+	return &SendTracker{
+		ttl:       ttl,
+		records:   make(map[int64]trackRecord),
+		lastPurge: time.Now(),
+	}
+}
+
+// 记录 msgid 对应的上下文.
+func (tracker *SendTracker) Track(msgid int64, value interface{}) {
+	now := time.Now()
+
+	tracker.mutex.Lock()
+	defer tracker.mutex.Unlock()
+
+	if now.Sub(tracker.lastPurge) >= tracker.ttl {
+		for id, record := range tracker.records {
+			if !now.Before(record.expireAt) {
+				delete(tracker.records, id)
+			}
+		}
+		tracker.lastPurge = now
+	}
+	tracker.records[msgid] = trackRecord{
+		value:    value,
+		expireAt: now.Add(tracker.ttl),
+	}
+}
+
+// 获取并删除 msgid 对应的上下文, 没有记录或者已经过期则 ok == false.
+func (tracker *SendTracker) Resolve(msgid int64) (value interface{}, ok bool) {
+	tracker.mutex.Lock()
+	defer tracker.mutex.Unlock()
+
+	record, ok := tracker.records[msgid]
+	if !ok {
+		return
+	}
+	delete(tracker.records, msgid)
+
+	if !time.Now().Before(record.expireAt) {
+		ok = false
+		return
+	}
+	value = record.value
+	return
+}
+
+// 获取并删除 event 对应的发送上下文, 见 Resolve.
+func (tracker *SendTracker) ResolveEvent(event *TemplateSendJobFinishEvent) (value interface{}, ok bool) {
+	return tracker.Resolve(event.MsgId)
+}
+
+// 发送模板消息, 发送成功后用 tracker 记录 msgid 和 value.
+func (clt *Client) SendTracked(msg *TemplateMessage, tracker *SendTracker, value interface{}) (msgid int64, err error) {
+	if msgid, err = clt.Send(msg); err != nil {
+		return
+	}
+	if tracker != nil {
+		tracker.Track(msgid, value)
+	}
+	return
+}
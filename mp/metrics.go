@@ -0,0 +1,77 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"strings"
+)
+
+// api 调用的统计接口, 通过 NewMetricsHook 设置到 Client.Hook.
+//  NOTE: MetricsCollector 的实现必须是并发安全的.
+type MetricsCollector interface {
+	// 每次 api 的 http 请求完成后调用.
+	//  family: api 的分类, see APIFamily
+	//  info:   请求的信息, 根据 info.Err, info.StatusCode, info.ErrCode 判断请求是否出错
+	ObserveAPICall(family string, info *RequestInfo)
+}
+
+// 获取 api 的分类, 用于统计.
+//  一般是 api 名称去掉 /cgi-bin 后的第一段, 比如:
+//  /cgi-bin/media/upload        -> media
+//  /cgi-bin/message/custom/send -> message
+//  /merchant/order/getbyid      -> merchant
+func APIFamily(api string) string {
+	api = strings.TrimPrefix(api, "/cgi-bin")
+	api = strings.TrimPrefix(api, "/")
+	if i := strings.IndexByte(api, '/'); i >= 0 {
+		api = api[:i]
+	}
+	if api == "" {
+		return "unknown"
+	}
+	return api
+}
+
+var _ Hook = metricsHook{}
+
+type metricsHook struct {
+	collector MetricsCollector
+}
+
+// 创建一个把每次 api 调用提交给 collector 统计的 Hook.
+func NewMetricsHook(collector MetricsCollector) Hook {
+	if collector == nil {
+		panic("nil MetricsCollector")
+	}
+	return metricsHook{collector: collector}
+}
+
+func (hook metricsHook) BeforeRequest(info *RequestInfo) {}
+
+func (hook metricsHook) AfterRequest(info *RequestInfo) {
+	hook.collector.ObserveAPICall(APIFamily(info.API), info)
+}
+
+var _ Hook = multiHook(nil)
+
+type multiHook []Hook
+
+// 把多个 Hook 组合为一个 Hook, 按顺序调用.
+func MultiHook(hooks ...Hook) Hook {
+	return multiHook(append([]Hook(nil), hooks...))
+}
+
+func (hooks multiHook) BeforeRequest(info *RequestInfo) {
+	for _, hook := range hooks {
+		hook.BeforeRequest(info)
+	}
+}
+
+func (hooks multiHook) AfterRequest(info *RequestInfo) {
+	for _, hook := range hooks {
+		hook.AfterRequest(info)
+	}
+}
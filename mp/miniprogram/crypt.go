@@ -0,0 +1,134 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package miniprogram
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/chanxuehong/util/security"
+)
+
+// 校验 wx.getUserInfo 返回的 rawData 的签名.
+//  signature = sha1(rawData + sessionKey)
+func CheckSignature(rawData, sessionKey, signature string) bool {
+	hashsum := sha1.Sum([]byte(rawData + sessionKey))
+	return security.SecureCompareString(hex.EncodeToString(hashsum[:]), signature)
+}
+
+// 解密开放数据, 比如 wx.getUserInfo, getPhoneNumber 返回的 encryptedData.
+//  算法为 AES-128-CBC, 数据采用 PKCS#7 填充, 密钥为 base64 解码后的 sessionKey, 初始向量为 base64 解码后的 iv.
+func Decrypt(sessionKey, encryptedData, iv string) (plaintext []byte, err error) {
+	aesKey, err := base64.StdEncoding.DecodeString(sessionKey)
+	if err != nil {
+		return
+	}
+	if len(aesKey) != 16 {
+		err = fmt.Errorf("the length of session_key must be 16, now is %d", len(aesKey))
+		return
+	}
+	aesIV, err := base64.StdEncoding.DecodeString(iv)
+	if err != nil {
+		return
+	}
+	if len(aesIV) != aes.BlockSize {
+		err = fmt.Errorf("the length of iv must be %d, now is %d", aes.BlockSize, len(aesIV))
+		return
+	}
+	ciphertext, err := base64.StdEncoding.DecodeString(encryptedData)
+	if err != nil {
+		return
+	}
+	if len(ciphertext) == 0 || len(ciphertext)%aes.BlockSize != 0 {
+		err = errors.New("encryptedData is not a multiple of the block size")
+		return
+	}
+
+	block, err := aes.NewCipher(aesKey)
+	if err != nil {
+		return
+	}
+	plaintext = make([]byte, len(ciphertext))
+	cipher.NewCBCDecrypter(block, aesIV).CryptBlocks(plaintext, ciphertext)
+
+	// 去掉 PKCS#7 填充
+	amountToPad := int(plaintext[len(plaintext)-1])
+	if amountToPad < 1 || amountToPad > aes.BlockSize {
+		plaintext = nil
+		err = errors.New("invalid PKCS#7 padding")
+		return
+	}
+	plaintext = plaintext[:len(plaintext)-amountToPad]
+	return
+}
+
+// 开放数据的水印
+type Watermark struct {
+	AppId     string `json:"appid"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+// wx.getUserInfo 的敏感数据
+type UserInfo struct {
+	OpenId    string    `json:"openId"`
+	UnionId   string    `json:"unionId,omitempty"`
+	Nickname  string    `json:"nickName"`
+	Gender    int       `json:"gender"` // 值为1时是男性, 值为2时是女性, 值为0时是未知
+	City      string    `json:"city"`
+	Province  string    `json:"province"`
+	Country   string    `json:"country"`
+	AvatarURL string    `json:"avatarUrl"`
+	Language  string    `json:"language"`
+	Watermark Watermark `json:"watermark"`
+}
+
+// getPhoneNumber 的敏感数据
+type PhoneNumber struct {
+	PhoneNumber     string    `json:"phoneNumber"`     // 用户绑定的手机号(国外手机号会有区号)
+	PurePhoneNumber string    `json:"purePhoneNumber"` // 没有区号的手机号
+	CountryCode     string    `json:"countryCode"`     // 区号
+	Watermark       Watermark `json:"watermark"`
+}
+
+// 解密 wx.getUserInfo 的 encryptedData, 并且校验水印的 appid.
+func DecryptUserInfo(appId, sessionKey, encryptedData, iv string) (info *UserInfo, err error) {
+	var userInfo UserInfo
+	if err = decryptTo(appId, sessionKey, encryptedData, iv, &userInfo, &userInfo.Watermark); err != nil {
+		return
+	}
+	info = &userInfo
+	return
+}
+
+// 解密 getPhoneNumber 的 encryptedData, 并且校验水印的 appid.
+func DecryptPhoneNumber(appId, sessionKey, encryptedData, iv string) (phone *PhoneNumber, err error) {
+	var phoneNumber PhoneNumber
+	if err = decryptTo(appId, sessionKey, encryptedData, iv, &phoneNumber, &phoneNumber.Watermark); err != nil {
+		return
+	}
+	phone = &phoneNumber
+	return
+}
+
+func decryptTo(appId, sessionKey, encryptedData, iv string, v interface{}, watermark *Watermark) (err error) {
+	plaintext, err := Decrypt(sessionKey, encryptedData, iv)
+	if err != nil {
+		return
+	}
+	if err = json.Unmarshal(plaintext, v); err != nil {
+		return
+	}
+	if watermark.AppId != appId {
+		return fmt.Errorf("the watermark's appid mismatch, have: %s, want: %s", watermark.AppId, appId)
+	}
+	return
+}
@@ -0,0 +1,9 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+// 小程序接口.
+//  小程序和公众号使用相同的 access_token 机制, 所以 Client 基于 mp.Client,
+//  使用小程序的 appid, appsecret 创建 mp.AccessTokenServer 即可.
+package miniprogram
@@ -0,0 +1,324 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package miniprogram
+
+import (
+	"errors"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 直播间的直播类型
+const (
+	LiveTypePhone = 0 // 手机直播
+	LiveTypePush  = 1 // 推流
+)
+
+// 直播间的状态
+const (
+	LiveStatusLiving    = 101 // 直播中
+	LiveStatusNotStart  = 102 // 未开始
+	LiveStatusEnded     = 103 // 已结束
+	LiveStatusForbidden = 104 // 禁播
+	LiveStatusPaused    = 105 // 暂停
+	LiveStatusError     = 106 // 异常
+	LiveStatusExpired   = 107 // 已过期
+)
+
+// 创建直播间的参数
+type LiveRoom struct {
+	Name            string `json:"name"`                      // 必须; 直播间名字, 最短3个汉字, 最长17个汉字
+	CoverImg        string `json:"coverImg"`                  // 必须; 背景图, 填入 media_id(通过临时素材接口上传)
+	StartTime       int64  `json:"startTime"`                 // 必须; 直播计划开始时间(unixtime), 开播时间需要在当前时间的10分钟后并且不能在6个月后
+	EndTime         int64  `json:"endTime"`                   // 必须; 直播计划结束时间(unixtime), 开播时间和结束时间间隔不得短于30分钟, 不得超过24小时
+	AnchorName      string `json:"anchorName"`                // 必须; 主播昵称, 最短2个汉字, 最长15个汉字
+	AnchorWechat    string `json:"anchorWechat"`              // 必须; 主播微信号, 需要通过实名认证
+	SubAnchorWechat string `json:"subAnchorWechat,omitempty"` // 主播副号微信号
+	CreaterWechat   string `json:"createrWechat,omitempty"`   // 创建者微信号
+	ShareImg        string `json:"shareImg"`                  // 必须; 分享图, 填入 media_id
+	FeedsImg        string `json:"feedsImg,omitempty"`        // 购物直播频道封面图, 填入 media_id
+	IsFeedsPublic   int    `json:"isFeedsPublic,omitempty"`   // 是否开启官方收录, 1 开启, 0 关闭
+	Type            int    `json:"type"`                      // 必须; 直播间类型, LiveTypePhone, LiveTypePush
+	CloseLike       int    `json:"closeLike"`                 // 必须; 是否关闭点赞, 0 开启, 1 关闭
+	CloseGoods      int    `json:"closeGoods"`                // 必须; 是否关闭货架, 0 开启, 1 关闭
+	CloseComment    int    `json:"closeComment"`              // 必须; 是否关闭评论, 0 开启, 1 关闭
+	CloseReplay     int    `json:"closeReplay,omitempty"`     // 是否关闭回放, 0 开启, 1 关闭
+	CloseShare      int    `json:"closeShare,omitempty"`      // 是否关闭分享, 0 开启, 1 关闭
+	CloseKf         int    `json:"closeKf,omitempty"`         // 是否关闭客服, 0 开启, 1 关闭
+}
+
+// 创建直播间.
+//  roomId:    直播间id
+//  qrcodeURL: 主播副号或者主播没有实名认证时返回, 需要主播扫码完成实名认证后才能开播, 否则为空
+func (clt *Client) CreateLiveRoom(room *LiveRoom) (roomId int64, qrcodeURL string, err error) {
+	if room == nil {
+		err = errors.New("nil LiveRoom")
+		return
+	}
+
+	var result struct {
+		mp.Error
+		RoomId    int64  `json:"roomId"`
+		QrcodeURL string `json:"qrcode_url"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/wxaapi/broadcast/room/create?access_token="
+	if err = ((*mp.Client)(clt)).PostJSON(incompleteURL, room, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	roomId = result.RoomId
+	qrcodeURL = result.QrcodeURL
+	return
+}
+
+// 直播间里的商品
+type LiveRoomGoods struct {
+	GoodsId         int64  `json:"goods_id"`          // 商品id
+	CoverImg        string `json:"cover_img"`         // 商品封面图链接
+	URL             string `json:"url"`               // 商品小程序路径
+	Name            string `json:"name"`              // 商品名称
+	Price           int    `json:"price"`             // 商品价格(分)
+	Price2          int    `json:"price2"`            // 商品价格(分), 使用方式看 PriceType
+	PriceType       int    `json:"price_type"`        // 1: 一口价, 2: 价格区间(Price 左边界, Price2 右边界), 3: 折扣价(Price 原价, Price2 现价)
+	ThirdPartyAppId string `json:"third_party_appid"` // 第三方商品的 appid, 当前小程序商品则为空
+}
+
+// 直播间信息
+type LiveRoomInfo struct {
+	Name          string          `json:"name"`            // 直播间名称
+	RoomId        int64           `json:"roomid"`          // 直播间id
+	CoverImg      string          `json:"cover_img"`       // 直播间背景图链接
+	ShareImg      string          `json:"share_img"`       // 直播间分享图链接
+	FeedsImg      string          `json:"feeds_img"`       // 官方收录封面
+	LiveStatus    int             `json:"live_status"`     // 直播间状态, LiveStatusXXX
+	StartTime     int64           `json:"start_time"`      // 直播间开始时间(unixtime)
+	EndTime       int64           `json:"end_time"`        // 直播计划结束时间(unixtime)
+	AnchorName    string          `json:"anchor_name"`     // 主播名
+	Goods         []LiveRoomGoods `json:"goods"`           // 直播间里的商品
+	LiveType      int             `json:"live_type"`       // 直播类型, LiveTypePhone, LiveTypePush
+	CloseLike     int             `json:"close_like"`      // 是否关闭点赞
+	CloseGoods    int             `json:"close_goods"`     // 是否关闭货架
+	CloseComment  int             `json:"close_comment"`   // 是否关闭评论
+	CloseKf       int             `json:"close_kf"`        // 是否关闭客服
+	CloseReplay   int             `json:"close_replay"`    // 是否关闭回放
+	IsFeedsPublic int             `json:"is_feeds_public"` // 是否开启官方收录
+	CreaterOpenId string          `json:"creater_openid"`  // 创建者openid
+}
+
+// 获取直播间列表.
+//  start: 起始拉取房间, 0 表示从第1个房间开始拉取
+//  limit: 每次拉取的房间个数, 建议100以内
+func (clt *Client) GetLiveInfo(start, limit int) (rooms []LiveRoomInfo, total int, err error) {
+	var request = struct {
+		Start int `json:"start"`
+		Limit int `json:"limit"`
+	}{
+		Start: start,
+		Limit: limit,
+	}
+
+	var result struct {
+		mp.Error
+		RoomInfo []LiveRoomInfo `json:"room_info"`
+		Total    int            `json:"total"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/wxa/business/getliveinfo?access_token="
+	if err = ((*mp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	rooms = result.RoomInfo
+	total = result.Total
+	return
+}
+
+// 直播回放的一个片段
+type LiveReplay struct {
+	ExpireTime string `json:"expire_time"` // 回放视频 url 过期时间
+	CreateTime string `json:"create_time"` // 回放视频创建时间
+	MediaURL   string `json:"media_url"`   // 回放视频链接
+}
+
+// 获取直播间的回放, 直播结束后才能获取.
+//  start: 起始拉取视频, 0 表示从第1个视频片段开始拉取
+//  limit: 每次拉取的数量, 建议100以内
+func (clt *Client) GetLiveReplay(roomId int64, start, limit int) (replays []LiveReplay, total int, err error) {
+	var request = struct {
+		Action string `json:"action"`
+		RoomId int64  `json:"room_id"`
+		Start  int    `json:"start"`
+		Limit  int    `json:"limit"`
+	}{
+		Action: "get_replay",
+		RoomId: roomId,
+		Start:  start,
+		Limit:  limit,
+	}
+
+	var result struct {
+		mp.Error
+		LiveReplay []LiveReplay `json:"live_replay"`
+		Total      int          `json:"total"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/wxa/business/getliveinfo?access_token="
+	if err = ((*mp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	replays = result.LiveReplay
+	total = result.Total
+	return
+}
+
+// 直播间商品的价格类型
+const (
+	LiveGoodsPriceTypeFixed    = 1 // 一口价, 只填 Price
+	LiveGoodsPriceTypeRange    = 2 // 价格区间, Price 左边界, Price2 右边界
+	LiveGoodsPriceTypeDiscount = 3 // 折扣价, Price 原价, Price2 现价
+)
+
+// 添加并提审的商品
+type LiveGoods struct {
+	CoverImgURL     string  `json:"coverImgUrl"`               // 必须; 商品图片, 填入 media_id(通过临时素材接口上传), 图片规则: 图片尺寸最大300像素*300像素
+	Name            string  `json:"name"`                      // 必须; 商品名称, 最长14个汉字
+	PriceType       int     `json:"priceType"`                 // 必须; 价格类型, LiveGoodsPriceTypeXXX
+	Price           float64 `json:"price"`                     // 必须; 价格(元)
+	Price2          float64 `json:"price2,omitempty"`          // 价格(元), 使用方式看 PriceType
+	URL             string  `json:"url"`                       // 必须; 商品详情页的小程序路径
+	ThirdPartyAppId string  `json:"thirdPartyAppid,omitempty"` // 当商品为第三方小程序的商品则填写为对应第三方小程序的appid, 自身小程序商品则为空
+}
+
+// 添加商品并提交审核.
+//  goodsId: 商品id
+//  auditId: 审核单id
+func (clt *Client) AddLiveGoods(goods *LiveGoods) (goodsId, auditId int64, err error) {
+	if goods == nil {
+		err = errors.New("nil LiveGoods")
+		return
+	}
+
+	var request = struct {
+		GoodsInfo *LiveGoods `json:"goodsInfo"`
+	}{
+		GoodsInfo: goods,
+	}
+
+	var result struct {
+		mp.Error
+		GoodsId int64 `json:"goodsId"`
+		AuditId int64 `json:"auditId"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/wxaapi/broadcast/goods/add?access_token="
+	if err = ((*mp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	goodsId = result.GoodsId
+	auditId = result.AuditId
+	return
+}
+
+// 商品的审核状态
+const (
+	LiveGoodsAuditStatusNotAudit = 0 // 未审核
+	LiveGoodsAuditStatusAuditing = 1 // 审核中
+	LiveGoodsAuditStatusPassed   = 2 // 审核通过
+	LiveGoodsAuditStatusRefused  = 3 // 审核驳回
+)
+
+// 商品的状态
+type LiveGoodsStatus struct {
+	GoodsId         int64   `json:"goods_id"`          // 商品id
+	CoverImgURL     string  `json:"cover_img_url"`     // 商品图片url
+	Name            string  `json:"name"`              // 商品名称
+	AuditStatus     int     `json:"audit_status"`      // 审核状态, LiveGoodsAuditStatusXXX
+	URL             string  `json:"url"`               // 商品小程序路径
+	Price           float64 `json:"price"`             // 价格(元)
+	Price2          float64 `json:"price2"`            // 价格(元), 使用方式看 PriceType
+	PriceType       int     `json:"price_type"`        // 价格类型, LiveGoodsPriceTypeXXX
+	ThirdPartyTag   int     `json:"third_party_tag"`   // 1, 2: 表示是为 api 添加商品, 否则是直播控制台添加的商品
+	ThirdPartyAppId string  `json:"third_party_appid"` // 第三方小程序的appid
+}
+
+// 获取商品的信息与审核状态.
+//  goodsIds: 商品id, 一次不超过20个
+func (clt *Client) GetLiveGoodsStatus(goodsIds []int64) (goods []LiveGoodsStatus, err error) {
+	if len(goodsIds) == 0 {
+		err = errors.New("empty goodsIds")
+		return
+	}
+
+	var request = struct {
+		GoodsIds []int64 `json:"goods_ids"`
+	}{
+		GoodsIds: goodsIds,
+	}
+
+	var result struct {
+		mp.Error
+		Goods []LiveGoodsStatus `json:"goods"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/wxa/business/getgoodswarehouse?access_token="
+	if err = ((*mp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	goods = result.Goods
+	return
+}
+
+// 往直播间导入已经审核通过的商品.
+func (clt *Client) AddLiveRoomGoods(roomId int64, goodsIds []int64) (err error) {
+	if len(goodsIds) == 0 {
+		return errors.New("empty goodsIds")
+	}
+
+	var request = struct {
+		Ids    []int64 `json:"ids"`
+		RoomId int64   `json:"roomId"`
+	}{
+		Ids:    goodsIds,
+		RoomId: roomId,
+	}
+
+	var result mp.Error
+
+	incompleteURL := "https://api.weixin.qq.com/wxaapi/broadcast/room/addgoods?access_token="
+	if err = ((*mp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
@@ -0,0 +1,189 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package miniprogram
+
+import (
+	"errors"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 插件的申请状态
+const (
+	PluginStatusApplying = 1 // 申请中
+	PluginStatusPassed   = 2 // 申请通过
+	PluginStatusRefused  = 3 // 被拒绝
+	PluginStatusExpired  = 4 // 已超时
+)
+
+// 已添加的插件
+type Plugin struct {
+	AppId      string `json:"appid"`      // 插件appid
+	Status     int    `json:"status"`     // 插件状态, PluginStatusXXX
+	Nickname   string `json:"nickname"`   // 插件昵称
+	HeadImgURL string `json:"headimgurl"` // 插件头像
+}
+
+// 向插件开发者发起使用插件的申请.
+//  reason: 申请使用的理由, 可以为空
+func (clt *Client) ApplyPlugin(pluginAppId, reason string) (err error) {
+	if pluginAppId == "" {
+		return errors.New("empty pluginAppId")
+	}
+
+	var request = struct {
+		Action      string `json:"action"`
+		PluginAppId string `json:"plugin_appid"`
+		Reason      string `json:"reason,omitempty"`
+	}{
+		Action:      "apply",
+		PluginAppId: pluginAppId,
+		Reason:      reason,
+	}
+	return clt.pluginAction("https://api.weixin.qq.com/wxa/plugin?access_token=", &request)
+}
+
+// 查询已添加的插件.
+func (clt *Client) GetPluginList() (plugins []Plugin, err error) {
+	var request = struct {
+		Action string `json:"action"`
+	}{
+		Action: "list",
+	}
+
+	var result struct {
+		mp.Error
+		PluginList []Plugin `json:"plugin_list"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/wxa/plugin?access_token="
+	if err = ((*mp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	plugins = result.PluginList
+	return
+}
+
+// 删除已添加的插件.
+func (clt *Client) UnbindPlugin(pluginAppId string) (err error) {
+	if pluginAppId == "" {
+		return errors.New("empty pluginAppId")
+	}
+
+	var request = struct {
+		Action      string `json:"action"`
+		PluginAppId string `json:"plugin_appid"`
+	}{
+		Action:      "unbind",
+		PluginAppId: pluginAppId,
+	}
+	return clt.pluginAction("https://api.weixin.qq.com/wxa/plugin?access_token=", &request)
+}
+
+// 其他小程序对插件的使用申请, 用于插件开发者.
+type PluginApply struct {
+	AppId      string `json:"appid"`      // 使用者的appid
+	Status     int    `json:"status"`     // 插件状态, PluginStatusXXX
+	Nickname   string `json:"nickname"`   // 使用者的昵称
+	HeadImgURL string `json:"headimgurl"` // 使用者的头像
+	Categories []struct {
+		First  string `json:"first"`
+		Second string `json:"second"`
+	} `json:"categories"` // 使用者的类目
+	CreateTime string `json:"create_time"` // 使用者的申请时间
+	ApplyURL   string `json:"apply_url"`   // 使用者的小程序码
+	Reason     string `json:"reason"`      // 使用者的申请说明
+}
+
+// 获取其他小程序对插件的使用申请列表, 用于插件开发者.
+//  page: 要拉取第几页的数据, 从 1 开始
+//  num:  每页的记录数
+func (clt *Client) GetPluginApplyList(page, num int) (applies []PluginApply, err error) {
+	var request = struct {
+		Action string `json:"action"`
+		Page   int    `json:"page"`
+		Num    int    `json:"num"`
+	}{
+		Action: "dev_apply_list",
+		Page:   page,
+		Num:    num,
+	}
+
+	var result struct {
+		mp.Error
+		ApplyList []PluginApply `json:"apply_list"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/wxa/devplugin?access_token="
+	if err = ((*mp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	applies = result.ApplyList
+	return
+}
+
+// 同意小程序 appId 使用插件的申请, 用于插件开发者.
+func (clt *Client) AgreePluginApply(appId string) (err error) {
+	if appId == "" {
+		return errors.New("empty appId")
+	}
+
+	var request = struct {
+		Action string `json:"action"`
+		AppId  string `json:"appid"`
+	}{
+		Action: "dev_agree",
+		AppId:  appId,
+	}
+	return clt.pluginAction("https://api.weixin.qq.com/wxa/devplugin?access_token=", &request)
+}
+
+// 拒绝使用插件的申请, 用于插件开发者.
+//  reason: 拒绝理由
+func (clt *Client) RefusePluginApply(reason string) (err error) {
+	var request = struct {
+		Action string `json:"action"`
+		Reason string `json:"reason"`
+	}{
+		Action: "dev_refuse",
+		Reason: reason,
+	}
+	return clt.pluginAction("https://api.weixin.qq.com/wxa/devplugin?access_token=", &request)
+}
+
+// 删除已拒绝的使用申请, 用于插件开发者.
+func (clt *Client) DeletePluginApply() (err error) {
+	var request = struct {
+		Action string `json:"action"`
+	}{
+		Action: "dev_delete",
+	}
+	return clt.pluginAction("https://api.weixin.qq.com/wxa/devplugin?access_token=", &request)
+}
+
+// 调用只返回 errcode, errmsg 的插件管理接口.
+func (clt *Client) pluginAction(incompleteURL string, request interface{}) (err error) {
+	var result mp.Error
+	if err = ((*mp.Client)(clt)).PostJSON(incompleteURL, request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
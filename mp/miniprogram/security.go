@@ -0,0 +1,211 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package miniprogram
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 内容安全检测的场景值
+const (
+	SecCheckSceneProfile = 1 // 资料
+	SecCheckSceneComment = 2 // 评论
+	SecCheckSceneForum   = 3 // 论坛
+	SecCheckSceneLog     = 4 // 社交日志
+)
+
+// 内容安全检测的建议
+const (
+	SecCheckSuggestRisky  = "risky"  // 违规
+	SecCheckSuggestPass   = "pass"   // 通过
+	SecCheckSuggestReview = "review" // 建议人工审核
+)
+
+// 文本内容安全检测的参数
+type MsgSecCheckParameters struct {
+	OpenId    string `json:"openid"`              // 必须; 用户的openid, 用户需在近两小时访问过小程序
+	Scene     int    `json:"scene"`               // 必须; 场景值, SecCheckSceneXXX
+	Content   string `json:"content"`             // 必须; 需检测的文本内容, 文本字数的上限为2500字
+	Title     string `json:"title,omitempty"`     // 文本标题
+	Nickname  string `json:"nickname,omitempty"`  // 用户昵称
+	Signature string `json:"signature,omitempty"` // 个性签名, 该参数仅在资料类场景有效(scene=1)
+}
+
+// 和 github.com/chanxuehong/wechat/mp.MediaCheckResult 一样, 同步修改
+type MediaCheckResult struct {
+	Suggest string `xml:"suggest" json:"suggest"` // 建议, SecCheckSuggestXXX
+	Label   int    `xml:"label"   json:"label"`   // 命中标签枚举值, 100 正常; 10001 广告; 20001 时政; 20002 色情; 20003 辱骂; 20006 违法犯罪; 20008 欺诈; 20012 低俗; 20013 版权; 21000 其他
+}
+
+// 和 github.com/chanxuehong/wechat/mp.MediaCheckDetail 一样, 同步修改
+type MediaCheckDetail struct {
+	Strategy string `xml:"strategy" json:"strategy"` // 策略类型
+	ErrCode  int    `xml:"errcode"  json:"errcode"`  // 错误码, 仅当该值为0时, 该项结果有效
+	Suggest  string `xml:"suggest"  json:"suggest"`  // 建议, SecCheckSuggestXXX
+	Label    int    `xml:"label"    json:"label"`    // 命中标签枚举值
+	Prob     int    `xml:"prob"     json:"prob"`     // 0-100, 代表置信度, 越高代表越有可能属于当前返回的标签
+}
+
+// 文本内容安全检测的结果
+type MsgSecCheckResult struct {
+	TraceId string             `json:"trace_id"` // 唯一请求标识
+	Result  MediaCheckResult   `json:"result"`   // 综合结果
+	Detail  []MediaCheckDetail `json:"detail"`   // 详细检测结果
+}
+
+// 文本内容安全检测(2.0 版本).
+func (clt *Client) MsgSecCheck(para *MsgSecCheckParameters) (rslt *MsgSecCheckResult, err error) {
+	if para == nil {
+		err = errors.New("nil MsgSecCheckParameters")
+		return
+	}
+
+	var request = struct {
+		Version int `json:"version"`
+		*MsgSecCheckParameters
+	}{
+		Version:               2,
+		MsgSecCheckParameters: para,
+	}
+
+	var result struct {
+		mp.Error
+		MsgSecCheckResult
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/wxa/msg_sec_check?access_token="
+	if err = ((*mp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	rslt = &result.MsgSecCheckResult
+	return
+}
+
+// 多媒体内容安全检测的媒体类型
+const (
+	MediaTypeAudio = 1 // 音频
+	MediaTypeImage = 2 // 图片
+)
+
+// 异步检测图片/音频是否含有违法违规内容(2.0 版本), 返回唯一请求标识 traceId.
+//  检测结果通过 wxa_media_check 事件推送, 见 MediaCheckEvent.
+//
+//  mediaURL:  要检测的图片或音频的url
+//  mediaType: MediaTypeAudio 或 MediaTypeImage
+//  openId:    用户的openid, 用户需在近两小时访问过小程序
+//  scene:     场景值, SecCheckSceneXXX
+func (clt *Client) MediaCheckAsync(mediaURL string, mediaType int, openId string, scene int) (traceId string, err error) {
+	if mediaURL == "" {
+		err = errors.New("empty mediaURL")
+		return
+	}
+
+	var request = struct {
+		MediaURL  string `json:"media_url"`
+		MediaType int    `json:"media_type"`
+		Version   int    `json:"version"`
+		OpenId    string `json:"openid"`
+		Scene     int    `json:"scene"`
+	}{
+		MediaURL:  mediaURL,
+		MediaType: mediaType,
+		Version:   2,
+		OpenId:    openId,
+		Scene:     scene,
+	}
+
+	var result struct {
+		mp.Error
+		TraceId string `json:"trace_id"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/wxa/media_check_async?access_token="
+	if err = ((*mp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	traceId = result.TraceId
+	return
+}
+
+const (
+	EventTypeMediaCheck = "wxa_media_check" // 异步多媒体内容安全检测结果
+)
+
+// 异步多媒体内容安全检测结果事件, 见 MediaCheckAsync.
+type MediaCheckEvent struct {
+	XMLName struct{} `xml:"xml" json:"-"`
+	mp.MessageHeader
+
+	Event   string             `xml:"Event"            json:"Event"`            // 事件类型, wxa_media_check
+	AppId   string             `xml:"appid"            json:"appid"`            // 小程序的appid
+	TraceId string             `xml:"trace_id"         json:"trace_id"`         // 任务id, MediaCheckAsync 返回的 traceId
+	Version int                `xml:"version"          json:"version"`          // 可用于区分接口版本
+	Result  MediaCheckResult   `xml:"result"           json:"result"`           // 综合结果
+	Detail  []MediaCheckDetail `xml:"detail,omitempty" json:"detail,omitempty"` // 详细检测结果
+}
+
+func GetMediaCheckEvent(msg *mp.MixedMessage) *MediaCheckEvent {
+	event := MediaCheckEvent{
+		MessageHeader: msg.MessageHeader,
+		Event:         msg.Event,
+		AppId:         msg.WxaAppId,
+		TraceId:       msg.TraceId,
+		Version:       msg.Version,
+		Result: MediaCheckResult{
+			Suggest: msg.MediaCheckResult.Suggest,
+			Label:   msg.MediaCheckResult.Label,
+		},
+	}
+	if n := len(msg.MediaCheckDetail); n > 0 {
+		event.Detail = make([]MediaCheckDetail, n)
+		for i, detail := range msg.MediaCheckDetail {
+			event.Detail[i] = MediaCheckDetail(detail)
+		}
+	}
+	if event.Version < 2 && event.Result.Suggest == "" { // 1.0 版本只有 isrisky
+		if msg.IsRisky != 0 {
+			event.Result.Suggest = SecCheckSuggestRisky
+		} else {
+			event.Result.Suggest = SecCheckSuggestPass
+		}
+	}
+	return &event
+}
+
+// 检测结果是否违规.
+func (event *MediaCheckEvent) Risky() bool {
+	return event.Result.Suggest == SecCheckSuggestRisky
+}
+
+var _ mp.MessageHandler = MediaCheckEventHandlerFunc(nil)
+
+// 异步多媒体内容安全检测结果事件的处理函数, 实现了 mp.MessageHandler 接口.
+type MediaCheckEventHandlerFunc func(http.ResponseWriter, *mp.Request, *MediaCheckEvent)
+
+func (fn MediaCheckEventHandlerFunc) ServeMessage(w http.ResponseWriter, r *mp.Request) {
+	fn(w, r, GetMediaCheckEvent(r.MixedMsg))
+}
+
+// 在 mux 上注册异步多媒体内容安全检测结果事件的处理函数.
+func HandleMediaCheckEvent(mux *mp.MessageServeMux, handler func(http.ResponseWriter, *mp.Request, *MediaCheckEvent)) {
+	if handler == nil {
+		panic("nil handler")
+	}
+	mux.EventHandle(EventTypeMediaCheck, MediaCheckEventHandlerFunc(handler))
+}
@@ -0,0 +1,106 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package miniprogram
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 登录凭证校验的结果.
+type Session struct {
+	OpenId     string `json:"openid"`            // 用户唯一标识
+	SessionKey string `json:"session_key"`       // 会话密钥
+	UnionId    string `json:"unionid,omitempty"` // 用户在开放平台的唯一标识符, 在满足 UnionID 下发条件的情况下会返回
+}
+
+// 登录凭证校验, 通过 wx.login 获得的 jsCode 换取 openid 和 session_key.
+//  如果 clt == nil 则默认使用 http.DefaultClient.
+func Code2Session(appId, appSecret, jsCode string, clt *http.Client) (session *Session, err error) {
+	if jsCode == "" {
+		err = errors.New("empty jsCode")
+		return
+	}
+	if clt == nil {
+		clt = http.DefaultClient
+	}
+
+	_url := "https://api.weixin.qq.com/sns/jscode2session?appid=" + url.QueryEscape(appId) +
+		"&secret=" + url.QueryEscape(appSecret) +
+		"&js_code=" + url.QueryEscape(jsCode) +
+		"&grant_type=authorization_code"
+	httpResp, err := clt.Get(_url)
+	if err != nil {
+		return
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		err = fmt.Errorf("http.Status: %s", httpResp.Status)
+		return
+	}
+
+	var result struct {
+		mp.Error
+		Session
+	}
+	if err = json.NewDecoder(httpResp.Body).Decode(&result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	session = &result.Session
+	return
+}
+
+const ErrCodeInvalidSignature = 87009 // 无效的签名, checksession 接口表示 session_key 已经失效
+
+// 检验登录态, 检查服务器保存的 sessionKey 是否还有效.
+func (clt *Client) CheckSessionKey(openId, sessionKey string) (valid bool, err error) {
+	if openId == "" {
+		err = errors.New("empty openId")
+		return
+	}
+	if sessionKey == "" {
+		err = errors.New("empty sessionKey")
+		return
+	}
+
+	// 用 session_key 对空字符串签名
+	h := hmac.New(sha256.New, []byte(sessionKey))
+	signature := hex.EncodeToString(h.Sum(nil))
+
+	var result mp.Error
+
+	incompleteURL := "https://api.weixin.qq.com/wxa/checksession?openid=" + url.QueryEscape(openId) +
+		"&signature=" + signature + "&sig_method=hmac_sha256&access_token="
+	if err = ((*mp.Client)(clt)).GetJSON(incompleteURL, &result); err != nil {
+		return
+	}
+
+	switch result.ErrCode {
+	case mp.ErrCodeOK:
+		valid = true
+		return
+	case ErrCodeInvalidSignature:
+		//valid = false
+		return
+	default:
+		err = &result
+		return
+	}
+}
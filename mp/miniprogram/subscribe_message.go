@@ -0,0 +1,250 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package miniprogram
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+const (
+	MiniProgramStateDeveloper = "developer" // 开发版
+	MiniProgramStateTrial     = "trial"     // 体验版
+	MiniProgramStateFormal    = "formal"    // 正式版
+)
+
+// 订阅消息模板内容的一个字段
+type SubscribeDataItem struct {
+	Value string `json:"value"`
+}
+
+// 订阅消息
+type SubscribeMessage struct {
+	ToUser           string                       `json:"touser"`                      // 必须; 接收者(用户)的 openid
+	TemplateId       string                       `json:"template_id"`                 // 必须; 所需下发的订阅模板id
+	Page             string                       `json:"page,omitempty"`              // 非必须; 点击模板卡片后的跳转页面, 仅限本小程序内的页面, 支持带参数
+	Data             map[string]SubscribeDataItem `json:"data"`                        // 必须; 模板内容, 格式形如 {"key1": {"value": any}, "key2": {"value": any}}
+	MiniProgramState string                       `json:"miniprogram_state,omitempty"` // 非必须; 跳转小程序类型, 默认为正式版
+	Lang             string                       `json:"lang,omitempty"`              // 非必须; 进入小程序查看的语言类型, 支持 zh_CN, en_US, zh_HK, zh_TW, 默认为 zh_CN
+}
+
+// 发送订阅消息.
+func (clt *Client) SubscribeMessageSend(msg *SubscribeMessage) (err error) {
+	if msg == nil {
+		err = errors.New("nil SubscribeMessage")
+		return
+	}
+
+	var result mp.Error
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/message/subscribe/send?access_token="
+	if err = ((*mp.Client)(clt)).PostJSON(incompleteURL, msg, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
+
+type TemplateCategory struct {
+	Id   int64  `json:"id"`   // 类目id, 查询公共库模版时需要
+	Name string `json:"name"` // 类目的中文名
+}
+
+// 获取小程序账号的类目.
+func (clt *Client) GetTemplateCategory() (categories []TemplateCategory, err error) {
+	var result struct {
+		mp.Error
+		Data []TemplateCategory `json:"data"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/wxaapi/newtmpl/getcategory?access_token="
+	if err = ((*mp.Client)(clt)).GetJSON(incompleteURL, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	categories = result.Data
+	return
+}
+
+type PubTemplateTitle struct {
+	TemplateId int64  `json:"tid"`        // 模版标题 id
+	Title      string `json:"title"`      // 模版标题
+	Type       int    `json:"type"`       // 模版类型, 2 为一次性订阅, 3 为长期订阅
+	CategoryId string `json:"categoryId"` // 模版所属类目 id
+}
+
+// 获取帐号所属类目下的公共模板标题.
+//  categoryIds: 类目 id 列表
+//  start:       用于分页, 表示从 start 开始, 从 0 开始计数
+//  limit:       用于分页, 表示拉取 limit 条记录, 最大为 30
+func (clt *Client) GetPubTemplateTitles(categoryIds []int64, start, limit int) (titles []PubTemplateTitle, count int, err error) {
+	if len(categoryIds) == 0 {
+		err = errors.New("empty categoryIds")
+		return
+	}
+
+	ids := make([]string, len(categoryIds))
+	for i, id := range categoryIds {
+		ids[i] = strconv.FormatInt(id, 10)
+	}
+
+	var result struct {
+		mp.Error
+		Count int                `json:"count"`
+		Data  []PubTemplateTitle `json:"data"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/wxaapi/newtmpl/getpubtemplatetitles?ids=" +
+		url.QueryEscape(strings.Join(ids, ",")) +
+		"&start=" + strconv.Itoa(start) +
+		"&limit=" + strconv.Itoa(limit) +
+		"&access_token="
+	if err = ((*mp.Client)(clt)).GetJSON(incompleteURL, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	titles = result.Data
+	count = result.Count
+	return
+}
+
+type PubTemplateKeyword struct {
+	KeywordId int64  `json:"kid"`     // 关键词 id, 选用模板时需要
+	Name      string `json:"name"`    // 关键词内容
+	Example   string `json:"example"` // 关键词内容对应的示例
+	Rule      string `json:"rule"`    // 参数类型
+}
+
+// 获取模板标题下的关键词列表.
+//  templateId: 模板标题 id
+func (clt *Client) GetPubTemplateKeywords(templateId int64) (keywords []PubTemplateKeyword, err error) {
+	var result struct {
+		mp.Error
+		Data []PubTemplateKeyword `json:"data"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/wxaapi/newtmpl/getpubtemplatekeywords?tid=" +
+		strconv.FormatInt(templateId, 10) + "&access_token="
+	if err = ((*mp.Client)(clt)).GetJSON(incompleteURL, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	keywords = result.Data
+	return
+}
+
+// 组合模板并添加至帐号下的个人模板库, 返回添加至帐号下的模板id.
+//  templateId: 模板标题 id
+//  keywordIds: 开发者自行组合好的模板关键词列表, 关键词顺序可以自由搭配, 最多支持5个, 最少2个关键词组合
+//  sceneDesc:  服务场景描述, 15个字以内
+func (clt *Client) AddTemplate(templateId int64, keywordIds []int64, sceneDesc string) (priTmplId string, err error) {
+	if n := len(keywordIds); n < 2 || n > 5 {
+		err = errors.New("the count of keywordIds must be between 2 and 5")
+		return
+	}
+
+	var request = struct {
+		TemplateId int64   `json:"tid"`
+		KeywordIds []int64 `json:"kidList"`
+		SceneDesc  string  `json:"sceneDesc,omitempty"`
+	}{
+		TemplateId: templateId,
+		KeywordIds: keywordIds,
+		SceneDesc:  sceneDesc,
+	}
+
+	var result struct {
+		mp.Error
+		PriTmplId string `json:"priTmplId"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/wxaapi/newtmpl/addtemplate?access_token="
+	if err = ((*mp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	priTmplId = result.PriTmplId
+	return
+}
+
+// 删除帐号下的个人模板.
+func (clt *Client) DeleteTemplate(priTmplId string) (err error) {
+	if priTmplId == "" {
+		err = errors.New("empty priTmplId")
+		return
+	}
+
+	var request = struct {
+		PriTmplId string `json:"priTmplId"`
+	}{
+		PriTmplId: priTmplId,
+	}
+
+	var result mp.Error
+
+	incompleteURL := "https://api.weixin.qq.com/wxaapi/newtmpl/deltemplate?access_token="
+	if err = ((*mp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
+
+type Template struct {
+	PriTmplId string `json:"priTmplId"` // 添加至帐号下的模板 id, 发送小程序订阅消息时所需
+	Title     string `json:"title"`     // 模版标题
+	Content   string `json:"content"`   // 模版内容
+	Example   string `json:"example"`   // 模板内容示例
+	Type      int    `json:"type"`      // 模版类型, 2 为一次性订阅, 3 为长期订阅
+}
+
+// 获取帐号下的个人模板列表.
+func (clt *Client) GetTemplateList() (templates []Template, err error) {
+	var result struct {
+		mp.Error
+		Data []Template `json:"data"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/wxaapi/newtmpl/gettemplate?access_token="
+	if err = ((*mp.Client)(clt)).GetJSON(incompleteURL, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	templates = result.Data
+	return
+}
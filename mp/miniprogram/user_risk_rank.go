@@ -0,0 +1,67 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package miniprogram
+
+import (
+	"errors"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 用户风险等级的场景
+const (
+	RiskRankSceneRegister  = 0 // 注册
+	RiskRankSceneMarketing = 1 // 营销作弊
+)
+
+// 获取用户风险等级的参数
+type UserRiskRankParameters struct {
+	AppId        string `json:"appid"`                   // 必须; 小程序appid
+	OpenId       string `json:"openid"`                  // 必须; 用户的openid
+	Scene        int    `json:"scene"`                   // 必须; 场景, RiskRankSceneXXX
+	MobileNo     string `json:"mobile_no,omitempty"`     // 用户手机号
+	ClientIP     string `json:"client_ip"`               // 必须; 用户访问源ip
+	EmailAddress string `json:"email_address,omitempty"` // 用户邮箱地址
+	ExtendedInfo string `json:"extended_info,omitempty"` // 额外补充信息
+	IsTest       bool   `json:"is_test,omitempty"`       // false: 正式调用, true: 测试调用
+}
+
+// 获取用户的风险等级, 用于注册, 营销等场景的反作弊.
+//  riskRank: 用户风险等级, 0 ~ 4, 数值越大风险越高
+//  unionId:  唯一请求标识, 标记单次请求
+func (clt *Client) GetUserRiskRank(para *UserRiskRankParameters) (riskRank int, unionId int64, err error) {
+	if para == nil {
+		err = errors.New("nil UserRiskRankParameters")
+		return
+	}
+	if para.OpenId == "" {
+		err = errors.New("empty openid")
+		return
+	}
+	if para.ClientIP == "" {
+		err = errors.New("empty client_ip")
+		return
+	}
+
+	var result struct {
+		mp.Error
+		RiskRank int   `json:"risk_rank"`
+		UnionId  int64 `json:"unoin_id"` // 微信返回的字段名就是 unoin_id
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/wxa/getuserriskrank?access_token="
+	if err = ((*mp.Client)(clt)).PostJSON(incompleteURL, para, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	riskRank = result.RiskRank
+	unionId = result.UnionId
+	return
+}
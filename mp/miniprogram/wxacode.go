@@ -0,0 +1,173 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package miniprogram
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 二维码线条的颜色, 在 AutoColor 为 false 时生效
+type LineColor struct {
+	R int `json:"r"`
+	G int `json:"g"`
+	B int `json:"b"`
+}
+
+// 获取小程序码的参数, 适用于需要的码数量较少的业务场景.
+type WXACodeParameters struct {
+	Path      string     `json:"path"`                 // 必须; 扫码进入的小程序页面路径, 最大长度 128 字节, 可以携带参数
+	Width     int        `json:"width,omitempty"`      // 非必须; 二维码的宽度, 单位 px, 最小 280px, 最大 1280px, 默认 430
+	AutoColor bool       `json:"auto_color,omitempty"` // 非必须; 自动配置线条颜色, 如果颜色依然是黑色, 则说明不建议配置主色调
+	LineColor *LineColor `json:"line_color,omitempty"` // 非必须; AutoColor 为 false 时生效
+	IsHyaline bool       `json:"is_hyaline,omitempty"` // 非必须; 是否需要透明底色, 为 true 时, 生成透明底色的小程序码
+}
+
+// 获取小程序码的参数, 适用于需要的码数量极多的业务场景.
+type WXACodeUnlimitedParameters struct {
+	Scene     string     `json:"scene"`                // 必须; 最大32个可见字符, 页面通过 scene 参数获取
+	Page      string     `json:"page,omitempty"`       // 非必须; 必须是已经发布的小程序存在的页面, 根路径前不要填加 /, 不能携带参数, 不填默认跳主页面
+	Width     int        `json:"width,omitempty"`      // 非必须; 二维码的宽度, 单位 px, 最小 280px, 最大 1280px, 默认 430
+	AutoColor bool       `json:"auto_color,omitempty"` // 非必须; 自动配置线条颜色, 如果颜色依然是黑色, 则说明不建议配置主色调
+	LineColor *LineColor `json:"line_color,omitempty"` // 非必须; AutoColor 为 false 时生效
+	IsHyaline bool       `json:"is_hyaline,omitempty"` // 非必须; 是否需要透明底色, 为 true 时, 生成透明底色的小程序码
+}
+
+// 获取小程序码, 写入 writer.
+//  和 WXAQRCodeCreate 总共生成的码数量限制为 100,000.
+func (clt *Client) WXACodeGet(para *WXACodeParameters, writer io.Writer) (written int64, err error) {
+	if para == nil {
+		err = errors.New("nil WXACodeParameters")
+		return
+	}
+	if para.Path == "" {
+		err = errors.New("empty Path")
+		return
+	}
+	if writer == nil {
+		err = errors.New("nil writer")
+		return
+	}
+	return clt.downloadToWriter("https://api.weixin.qq.com/wxa/getwxacode?access_token=", para, writer)
+}
+
+// 获取小程序码, 写入 writer.
+//  通过该接口生成的小程序码, 永久有效, 数量暂无限制.
+func (clt *Client) WXACodeGetUnlimited(para *WXACodeUnlimitedParameters, writer io.Writer) (written int64, err error) {
+	if para == nil {
+		err = errors.New("nil WXACodeUnlimitedParameters")
+		return
+	}
+	if para.Scene == "" {
+		err = errors.New("empty Scene")
+		return
+	}
+	if writer == nil {
+		err = errors.New("nil writer")
+		return
+	}
+	return clt.downloadToWriter("https://api.weixin.qq.com/wxa/getwxacodeunlimit?access_token=", para, writer)
+}
+
+// 获取小程序二维码, 写入 writer.
+//  path:  扫码进入的小程序页面路径, 最大长度 128 字节
+//  width: 二维码的宽度, 单位 px, 最小 280px, 最大 1280px, <= 0 表示使用默认值 430
+func (clt *Client) WXAQRCodeCreate(path string, width int, writer io.Writer) (written int64, err error) {
+	if path == "" {
+		err = errors.New("empty path")
+		return
+	}
+	if writer == nil {
+		err = errors.New("nil writer")
+		return
+	}
+
+	var request = struct {
+		Path  string `json:"path"`
+		Width int    `json:"width,omitempty"`
+	}{
+		Path:  path,
+		Width: width,
+	}
+	return clt.downloadToWriter("https://api.weixin.qq.com/cgi-bin/wxaapp/createwxaqrcode?access_token=", &request, writer)
+}
+
+// POST 请求 incompleteURL, 返回的图片写入 writer.
+func (clt *Client) downloadToWriter(incompleteURL string, request interface{}, writer io.Writer) (written int64, err error) {
+	requestBody, err := json.Marshal(request)
+	if err != nil {
+		return
+	}
+
+	token, err := clt.Token()
+	if err != nil {
+		return
+	}
+
+	hasRetried := false
+	failoverCount := 0
+RETRY:
+	finalURL := ((*mp.Client)(clt)).APIURL(incompleteURL) + url.QueryEscape(token)
+
+	httpResp, err := clt.HttpClient.Post(finalURL, "application/json; charset=utf-8", bytes.NewReader(requestBody))
+	if err != nil {
+		if ((*mp.Client)(clt)).APIHostFailed(incompleteURL, finalURL, err, 0, &failoverCount) {
+			goto RETRY
+		}
+		return
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		((*mp.Client)(clt)).APIHostFailed(incompleteURL, finalURL, nil, httpResp.StatusCode, &failoverCount)
+		err = fmt.Errorf("http.Status: %s", httpResp.Status)
+		return
+	}
+
+	ContentType, _, _ := mime.ParseMediaType(httpResp.Header.Get("Content-Type"))
+	if ContentType != "text/plain" && ContentType != "application/json" { // 返回的是图片
+		return io.Copy(writer, httpResp.Body)
+	}
+
+	// 返回的是错误信息
+	var result mp.Error
+	if err = json.NewDecoder(httpResp.Body).Decode(&result); err != nil {
+		return
+	}
+
+	switch result.ErrCode {
+	case mp.ErrCodeOK:
+		return // 基本不会出现
+	case mp.ErrCodeInvalidCredential, mp.ErrCodeInvalidAccessToken, mp.ErrCodeAccessTokenExpired: // 失效(过期)重试一次
+		mp.LogInfoln("[WECHAT_RETRY] err_code:", result.ErrCode, ", err_msg:", result.ErrMsg)
+		mp.LogInfoln("[WECHAT_RETRY] current token:", token)
+
+		if !hasRetried {
+			hasRetried = true
+
+			if token, err = clt.TokenRefresh(); err != nil {
+				return
+			}
+			mp.LogInfoln("[WECHAT_RETRY] new token:", token)
+
+			result = mp.Error{}
+			goto RETRY
+		}
+		mp.LogInfoln("[WECHAT_RETRY] fallthrough, current token:", token)
+		fallthrough
+	default:
+		err = &result
+		return
+	}
+}
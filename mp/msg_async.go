@@ -0,0 +1,150 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// 异步处理的消息(事件), 只包含可以序列化的数据, 方便放入 NSQ, Kafka 等消息队列.
+type AsyncMessage struct {
+	AppId     string        `json:"appid,omitempty"` // 安全模式下消息所属的 AppId, 明文模式为空
+	RawMsgXML []byte        `json:"raw_msg_xml"`     // 消息的 XML 文本, 对于加密模式是解密后的消息
+	MixedMsg  *MixedMessage `json:"mixed_msg"`       // RawMsgXML 解析后的消息
+}
+
+// 异步处理消息(事件)的队列接口, 可以用 NSQ, Kafka 等实现.
+//  NOTE: MessageQueue 的实现必须是并发安全的, Push 不能阻塞太久.
+type MessageQueue interface {
+	Push(msg *AsyncMessage) (err error)
+}
+
+var _ MessageHandler = (*AsyncHandler)(nil)
+
+// 异步处理消息(事件)的 MessageHandler.
+//  微信服务器 5 秒内收不到响应会重试, AsyncHandler 把消息放入队列后立即回复 "success",
+//  由队列的消费者处理消息, 所以不能被动回复消息, 需要回复的请用客服消息接口.
+//  放入队列失败时回复 503, 微信服务器会重试.
+type AsyncHandler struct {
+	queue MessageQueue
+}
+
+func NewAsyncHandler(queue MessageQueue) *AsyncHandler {
+	if queue == nil {
+		panic("nil MessageQueue")
+	}
+	return &AsyncHandler{
+		queue: queue,
+	}
+}
+
+func (handler *AsyncHandler) ServeMessage(w http.ResponseWriter, r *Request) {
+	msg := &AsyncMessage{
+		AppId:     r.AppId,
+		RawMsgXML: append([]byte(nil), r.RawMsgXML...),
+		MixedMsg:  r.MixedMsg,
+	}
+	if err := handler.queue.Push(msg); err != nil {
+		// 放入队列失败返回 503, 让微信服务器重试;
+		// 如果什么都不写, net/http 会回复空的 200, 微信服务器认为已经送达, 不会重试.
+		LogInfoln("[WECHAT_ASYNC] push error:", err)
+		http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+		return
+	}
+	io.WriteString(w, "success")
+}
+
+// 队列已满
+var ErrMessageQueueFull = errors.New("message queue is full")
+
+// 队列已关闭
+var ErrMessageQueueClosed = errors.New("message queue is closed")
+
+var _ MessageQueue = (*ChanMessageQueue)(nil)
+
+// 基于 channel 的 MessageQueue, 启动 workers 个 goroutine 调用 fn 处理消息, 只能用于单进程环境.
+type ChanMessageQueue struct {
+	ch chan *AsyncMessage
+	fn func(msg *AsyncMessage)
+	wg sync.WaitGroup
+
+	rwmutex sync.RWMutex
+	closed  bool
+}
+
+// 创建一个新的 ChanMessageQueue.
+//  size:    队列的容量, 队列满了 Push 返回 ErrMessageQueueFull
+//  workers: 处理消息的 goroutine 个数, <= 0 时为 1
+//  fn:      处理消息的函数, fn 里的 panic 会被捕获并记录日志
+func NewChanMessageQueue(size, workers int, fn func(msg *AsyncMessage)) *ChanMessageQueue {
+	if fn == nil {
+		panic("nil fn")
+	}
+	if size < 0 {
+		size = 0
+	}
+	if workers <= 0 {
+		workers = 1
+	}
+
+	queue := &ChanMessageQueue{
+		ch: make(chan *AsyncMessage, size),
+		fn: fn,
+	}
+	queue.wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go queue.work()
+	}
+	return queue
+}
+
+func (queue *ChanMessageQueue) Push(msg *AsyncMessage) (err error) {
+	queue.rwmutex.RLock()
+	defer queue.rwmutex.RUnlock()
+
+	if queue.closed {
+		return ErrMessageQueueClosed
+	}
+	select {
+	case queue.ch <- msg:
+		return
+	default:
+		return ErrMessageQueueFull
+	}
+}
+
+// 关闭队列, 等待队列里的消息处理完毕后返回.
+func (queue *ChanMessageQueue) Close() {
+	queue.rwmutex.Lock()
+	if queue.closed {
+		queue.rwmutex.Unlock()
+		return
+	}
+	queue.closed = true
+	close(queue.ch)
+	queue.rwmutex.Unlock()
+
+	queue.wg.Wait()
+}
+
+func (queue *ChanMessageQueue) work() {
+	defer queue.wg.Done()
+	for msg := range queue.ch {
+		queue.handle(msg)
+	}
+}
+
+func (queue *ChanMessageQueue) handle(msg *AsyncMessage) {
+	defer func() {
+		if v := recover(); v != nil {
+			LogInfoln("[WECHAT_ASYNC] panic:", v)
+		}
+	}()
+	queue.fn(msg)
+}
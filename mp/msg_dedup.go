@@ -0,0 +1,119 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// 消息去重的缓存接口, 可以用 redis 等实现, 用于多个进程之间去重.
+//  NOTE: DedupCache 的实现必须是并发安全的.
+type DedupCache interface {
+	// 如果 key 不存在则保存 key 并返回 true, 否则返回 false.
+	//  保存的 key 至少要保留 ttl 时间.
+	Add(key string, ttl time.Duration) (added bool, err error)
+}
+
+// 微信服务器在 5 秒内收不到响应会重试, 共 3 次, 所以去重的 key 保留 20 秒足够了.
+const DefaultDedupTTL = 20 * time.Second
+
+var _ MessageHandler = (*DedupHandler)(nil)
+
+// 消息(事件)去重的 MessageHandler 中间件.
+//  微信服务器在 5 秒内收不到响应会断掉连接并重试, 共重试 3 次, 这样同一条消息 handler 可能会被调用多次,
+//  DedupHandler 保证同一条消息只有第一次推送会交给 handler 处理, 重复的推送直接回复空串.
+//
+//  消息用 MsgId 去重, 事件(没有 MsgId)用 FromUserName + CreateTime + Event 去重.
+type DedupHandler struct {
+	handler MessageHandler
+	cache   DedupCache
+	ttl     time.Duration
+}
+
+// 创建一个新的 DedupHandler.
+//  如果 cache == nil 则使用 NewMemoryDedupCache(), 只能用于单进程环境.
+//  如果 ttl <= 0 则使用 DefaultDedupTTL.
+func NewDedupHandler(handler MessageHandler, cache DedupCache, ttl time.Duration) *DedupHandler {
+	if handler == nil {
+		panic("nil MessageHandler")
+	}
+	if cache == nil {
+		cache = NewMemoryDedupCache()
+	}
+	if ttl <= 0 {
+		ttl = DefaultDedupTTL
+	}
+
+	return &DedupHandler{
+		handler: handler,
+		cache:   cache,
+		ttl:     ttl,
+	}
+}
+
+func (handler *DedupHandler) ServeMessage(w http.ResponseWriter, r *Request) {
+	added, err := handler.cache.Add(dedupKey(r), handler.ttl)
+	if err != nil {
+		// 缓存出错的时候宁可重复处理也不要丢消息
+		LogInfoln("[WECHAT_DEDUP] cache error:", err)
+		handler.handler.ServeMessage(w, r)
+		return
+	}
+	if !added {
+		return // 重复的推送, 回复空串
+	}
+	handler.handler.ServeMessage(w, r)
+}
+
+func dedupKey(r *Request) string {
+	msg := r.MixedMsg
+	if msg.MsgId != 0 {
+		return msg.ToUserName + ":" + strconv.FormatInt(msg.MsgId, 10)
+	}
+	return msg.ToUserName + ":" + msg.FromUserName + ":" + strconv.FormatInt(msg.CreateTime, 10) + ":" + msg.Event
+}
+
+var _ DedupCache = (*MemoryDedupCache)(nil)
+
+// 基于内存的 DedupCache 实现, 用于单进程环境.
+type MemoryDedupCache struct {
+	mutex     sync.Mutex
+	keys      map[string]time.Time // map[key]过期时间
+	lastPrune time.Time
+}
+
+func NewMemoryDedupCache() *MemoryDedupCache {
+	return &MemoryDedupCache{
+		keys:      make(map[string]time.Time),
+		lastPrune: time.Now(),
+	}
+}
+
+func (cache *MemoryDedupCache) Add(key string, ttl time.Duration) (added bool, err error) {
+	now := time.Now()
+
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	// 每隔一段时间清除过期的 key
+	if now.Sub(cache.lastPrune) > ttl {
+		for k, expiresAt := range cache.keys {
+			if !now.Before(expiresAt) {
+				delete(cache.keys, k)
+			}
+		}
+		cache.lastPrune = now
+	}
+
+	if expiresAt, ok := cache.keys[key]; ok && now.Before(expiresAt) {
+		return false, nil
+	}
+	cache.keys[key] = now.Add(ttl)
+	return true, nil
+}
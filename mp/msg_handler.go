@@ -41,6 +41,8 @@ type Request struct {
 	AESKey       [32]byte // 当前消息 AES 加密的 key
 	Random       []byte   // 当前消息加密时所用的 random, 16 bytes
 	AppId        string   // 当前消息加密时所用的 AppId
+
+	Session *Session // 用户的会话, 使用 SessionMiddleware 时有效, 否则为 nil
 }
 
 // 微信服务器推送过来的消息(事件)通用的消息头
@@ -75,6 +77,7 @@ type MixedMessage struct {
 
 	Event    string `xml:"Event"    json:"Event"`
 	EventKey string `xml:"EventKey" json:"EventKey"`
+	MenuId   int64  `xml:"MenuId"   json:"MenuId"`
 
 	ScanCodeInfo struct {
 		ScanType   string `xml:"ScanType"   json:"ScanType"`
@@ -106,6 +109,8 @@ type MixedMessage struct {
 	SentCount   int     `xml:"SentCount"   json:"SentCount"`
 	ErrorCount  int     `xml:"ErrorCount"  json:"ErrorCount"`
 
+	CopyrightCheckResult CopyrightCheckResult `xml:"CopyrightCheckResult" json:"CopyrightCheckResult"`
+
 	// merchant
 	OrderId     string `xml:"OrderId"     json:"OrderId"`
 	OrderStatus int    `xml:"OrderStatus" json:"OrderStatus"`
@@ -142,6 +147,15 @@ type MixedMessage struct {
 	VendorId    string `xml:"VendorId"    json:"VendorId"`
 	PlaceId     int64  `xml:"PlaceId"     json:"PlaceId"`
 	DeviceNo    string `xml:"DeviceNo"    json:"DeviceNo"`
+
+	// wxa_media_check
+	WxaAppId         string             `xml:"appid"            json:"appid"`
+	TraceId          string             `xml:"trace_id"         json:"trace_id"`
+	Version          int                `xml:"version"          json:"version"`
+	MediaCheckResult MediaCheckResult   `xml:"result"           json:"result"`
+	MediaCheckDetail []MediaCheckDetail `xml:"detail,omitempty" json:"detail,omitempty"`
+	IsRisky          int                `xml:"isrisky"          json:"isrisky"`     // version 1
+	StatusCode       int                `xml:"status_code"      json:"status_code"` // version 1
 }
 
 // 和 github.com/chanxuehong/wechat/mp/shakearound.ChosenBeacon 一样, 同步修改
@@ -159,3 +173,37 @@ type AroundBeacon struct {
 	Minor    int     `xml:"Minor"    json:"Minor"`
 	Distance float64 `xml:"Distance" json:"Distance"`
 }
+
+// 和 github.com/chanxuehong/wechat/mp/message/mass.CopyrightCheckResult 一样, 同步修改
+type CopyrightCheckResult struct {
+	Count      int                        `xml:"Count"                     json:"Count"`
+	ResultList []CopyrightCheckResultItem `xml:"ResultList>item,omitempty" json:"ResultList,omitempty"`
+	CheckState int                        `xml:"CheckState"                json:"CheckState"`
+}
+
+// 和 github.com/chanxuehong/wechat/mp/message/mass.CopyrightCheckResultItem 一样, 同步修改
+type CopyrightCheckResultItem struct {
+	ArticleIdx            int    `xml:"ArticleIdx"            json:"ArticleIdx"`
+	UserDeclareState      int    `xml:"UserDeclareState"      json:"UserDeclareState"`
+	AuditState            int    `xml:"AuditState"            json:"AuditState"`
+	OriginalArticleURL    string `xml:"OriginalArticleUrl"    json:"OriginalArticleUrl"`
+	OriginalArticleType   int    `xml:"OriginalArticleType"   json:"OriginalArticleType"`
+	CanReprint            int    `xml:"CanReprint"            json:"CanReprint"`
+	NeedReplaceContent    int    `xml:"NeedReplaceContent"    json:"NeedReplaceContent"`
+	NeedShowReprintSource int    `xml:"NeedShowReprintSource" json:"NeedShowReprintSource"`
+}
+
+// 和 github.com/chanxuehong/wechat/mp/miniprogram.MediaCheckResult 一样, 同步修改
+type MediaCheckResult struct {
+	Suggest string `xml:"suggest" json:"suggest"`
+	Label   int    `xml:"label"   json:"label"`
+}
+
+// 和 github.com/chanxuehong/wechat/mp/miniprogram.MediaCheckDetail 一样, 同步修改
+type MediaCheckDetail struct {
+	Strategy string `xml:"strategy" json:"strategy"`
+	ErrCode  int    `xml:"errcode"  json:"errcode"`
+	Suggest  string `xml:"suggest"  json:"suggest"`
+	Label    int    `xml:"label"    json:"label"`
+	Prob     int    `xml:"prob"     json:"prob"`
+}
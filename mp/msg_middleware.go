@@ -0,0 +1,100 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"io"
+	"net/http"
+	"runtime"
+	"time"
+)
+
+// MessageHandler 中间件, 包装 handler 返回一个新的 MessageHandler.
+type Middleware func(handler MessageHandler) MessageHandler
+
+// 用 middlewares 依次包装 handler, middlewares[0] 在最外层, 最先处理消息(事件).
+//
+//  handler = mp.Chain(mux, mp.RecoverMiddleware, mp.LogMiddleware, mp.TimingMiddleware(observe))
+func Chain(handler MessageHandler, middlewares ...Middleware) MessageHandler {
+	if handler == nil {
+		panic("nil MessageHandler")
+	}
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](handler)
+	}
+	return handler
+}
+
+// 捕获 handler 的 panic, 记录日志和调用栈; 如果还没有回复过则回复 "success",
+// 避免微信服务器重试以及给用户提示 "该公众号暂时无法提供服务".
+func RecoverMiddleware(handler MessageHandler) MessageHandler {
+	return MessageHandlerFunc(func(w http.ResponseWriter, r *Request) {
+		rw := &wroteResponseWriter{ResponseWriter: w}
+		defer func() {
+			if v := recover(); v != nil {
+				stack := make([]byte, 4<<10)
+				stack = stack[:runtime.Stack(stack, false)]
+				LogInfoln("[WECHAT_RECOVER] panic:", v, "\n", string(stack))
+
+				if !rw.wrote {
+					io.WriteString(w, "success")
+				}
+			}
+		}()
+		handler.ServeMessage(rw, r)
+	})
+}
+
+// 记录每个消息(事件)的日志.
+func LogMiddleware(handler MessageHandler) MessageHandler {
+	return MessageHandlerFunc(func(w http.ResponseWriter, r *Request) {
+		if msg := r.MixedMsg; msg != nil {
+			LogInfoln("[WECHAT_MESSAGE] from:", msg.FromUserName, ", to:", msg.ToUserName,
+				", msg_type:", msg.MsgType, ", event:", msg.Event, ", msg_id:", msg.MsgId)
+		}
+		handler.ServeMessage(w, r)
+	})
+}
+
+// 统计 handler 处理每个消息(事件)的耗时, 处理完成后调用 observe.
+//  微信服务器 5 秒内收不到响应会重试, 可以根据 latency 报警.
+func TimingMiddleware(observe func(r *Request, latency time.Duration)) Middleware {
+	if observe == nil {
+		panic("nil observe")
+	}
+	return func(handler MessageHandler) MessageHandler {
+		return MessageHandlerFunc(func(w http.ResponseWriter, r *Request) {
+			start := time.Now()
+			defer func() {
+				observe(r, time.Since(start))
+			}()
+			handler.ServeMessage(w, r)
+		})
+	}
+}
+
+// 消息(事件)去重的中间件, 见 NewDedupHandler.
+func DedupMiddleware(cache DedupCache, ttl time.Duration) Middleware {
+	return func(handler MessageHandler) MessageHandler {
+		return NewDedupHandler(handler, cache, ttl)
+	}
+}
+
+// 记录是否已经写入过 http 响应.
+type wroteResponseWriter struct {
+	http.ResponseWriter
+	wrote bool
+}
+
+func (w *wroteResponseWriter) WriteHeader(code int) {
+	w.wrote = true
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *wroteResponseWriter) Write(p []byte) (int, error) {
+	w.wrote = true
+	return w.ResponseWriter.Write(p)
+}
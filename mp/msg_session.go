@@ -0,0 +1,266 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// 会话的存储接口, 可以用 redis 等实现, 用于多个进程之间共享会话.
+//  NOTE: SessionStore 的实现必须是并发安全的.
+type SessionStore interface {
+	// 获取 key 对应的会话数据, 不存在或者已经过期返回 nil, nil.
+	Get(key string) (data []byte, err error)
+
+	// 保存 key 对应的会话数据, ttl 后过期.
+	Set(key string, data []byte, ttl time.Duration) (err error)
+
+	// 删除 key 对应的会话数据.
+	Delete(key string) (err error)
+}
+
+// 默认的会话过期时间
+const DefaultSessionTTL = 5 * time.Minute
+
+// 用户的会话, 用于实现多步骤的对话, 比如 "回复 1 绑定帐号".
+//  会话以 公众号(ToUserName) + 用户(FromUserName) 为 key, 每次收到该用户的消息(事件)都会刷新过期时间.
+//  NOTE: Session 不是并发安全的, 只能在处理当前消息的 goroutine 中使用.
+type Session struct {
+	key       string
+	values    map[string]string
+	modified  bool
+	destroyed bool
+}
+
+// 获取 name 对应的值, 不存在返回 "".
+func (session *Session) Get(name string) string {
+	return session.values[name]
+}
+
+// 获取 name 对应的值, 并返回是否存在.
+func (session *Session) Lookup(name string) (value string, ok bool) {
+	value, ok = session.values[name]
+	return
+}
+
+func (session *Session) Set(name, value string) {
+	if session.values == nil {
+		session.values = make(map[string]string)
+	}
+	session.values[name] = value
+	session.modified = true
+}
+
+func (session *Session) Delete(name string) {
+	if _, ok := session.values[name]; ok {
+		delete(session.values, name)
+		session.modified = true
+	}
+}
+
+// 销毁会话, 处理完当前消息后从 SessionStore 中删除.
+func (session *Session) Destroy() {
+	session.values = nil
+	session.destroyed = true
+}
+
+// 会话的状态, 即 Get("state"), 方便实现简单的状态机.
+func (session *Session) State() string {
+	return session.Get("state")
+}
+
+func (session *Session) SetState(state string) {
+	session.Set("state", state)
+}
+
+// 会话的中间件, 处理消息(事件)前从 store 加载用户的会话并设置 r.Session, 处理完后保存到 store.
+//  如果 store == nil 则使用 NewMemorySessionStore(), 只能用于单进程环境.
+//  如果 ttl <= 0 则使用 DefaultSessionTTL.
+func SessionMiddleware(store SessionStore, ttl time.Duration) Middleware {
+	if store == nil {
+		store = NewMemorySessionStore()
+	}
+	if ttl <= 0 {
+		ttl = DefaultSessionTTL
+	}
+
+	return func(handler MessageHandler) MessageHandler {
+		return MessageHandlerFunc(func(w http.ResponseWriter, r *Request) {
+			session, err := loadSession(store, r)
+			if err != nil {
+				// 会话存储出错的时候不中断消息处理, handler 看到的是一个新的会话
+				LogInfoln("[WECHAT_SESSION] load error:", err)
+			}
+			r.Session = session
+
+			handler.ServeMessage(w, r)
+
+			if err = saveSession(store, session, ttl); err != nil {
+				LogInfoln("[WECHAT_SESSION] save error:", err)
+			}
+		})
+	}
+}
+
+func loadSession(store SessionStore, r *Request) (session *Session, err error) {
+	session = &Session{
+		key: r.MixedMsg.ToUserName + ":" + r.MixedMsg.FromUserName,
+	}
+
+	data, err := store.Get(session.key)
+	if err != nil || data == nil {
+		return
+	}
+	var values map[string]string
+	if err = json.Unmarshal(data, &values); err != nil {
+		err = fmt.Errorf("invalid session data: %s", err.Error())
+		return
+	}
+	session.values = values
+	return
+}
+
+func saveSession(store SessionStore, session *Session, ttl time.Duration) (err error) {
+	if session.destroyed {
+		return store.Delete(session.key)
+	}
+	if len(session.values) == 0 && !session.modified {
+		return
+	}
+	data, err := json.Marshal(session.values)
+	if err != nil {
+		return
+	}
+	return store.Set(session.key, data, ttl) // 没有修改也要保存, 刷新过期时间
+}
+
+var _ SessionStore = (*MemorySessionStore)(nil)
+
+// SessionStore 的内存实现, 只能用于单进程环境.
+type MemorySessionStore struct {
+	mutex     sync.Mutex
+	sessions  map[string]memorySession
+	lastPurge time.Time
+}
+
+type memorySession struct {
+	data     []byte
+	expireAt time.Time
+}
+
+func NewMemorySessionStore() *MemorySessionStore {
+	return &MemorySessionStore{
+		sessions:  make(map[string]memorySession),
+		lastPurge: time.Now(),
+	}
+}
+
+func (store *MemorySessionStore) Get(key string) (data []byte, err error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	session, ok := store.sessions[key]
+	if !ok {
+		return
+	}
+	if !time.Now().Before(session.expireAt) {
+		delete(store.sessions, key)
+		return
+	}
+	data = session.data
+	return
+}
+
+func (store *MemorySessionStore) Set(key string, data []byte, ttl time.Duration) (err error) {
+	now := time.Now()
+
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	// 每分钟至多清理一次过期的会话
+	if now.Sub(store.lastPurge) >= time.Minute {
+		for k, session := range store.sessions {
+			if !now.Before(session.expireAt) {
+				delete(store.sessions, k)
+			}
+		}
+		store.lastPurge = now
+	}
+	store.sessions[key] = memorySession{
+		data:     data,
+		expireAt: now.Add(ttl),
+	}
+	return
+}
+
+func (store *MemorySessionStore) Delete(key string) (err error) {
+	store.mutex.Lock()
+	delete(store.sessions, key)
+	store.mutex.Unlock()
+	return
+}
+
+var _ SessionStore = (*RedisSessionStore)(nil)
+
+// 基于 redis 的 SessionStore 实现, 用于多台机器共享会话.
+type RedisSessionStore struct {
+	getConn   func() RedisConn // 获取 redis 连接, 用完后会调用 Close
+	keyPrefix string           // 会话在 redis 里的 key 为 keyPrefix + 会话的 key
+}
+
+// 创建一个新的 RedisSessionStore.
+//  getConn:   获取 redis 连接的函数, 比如 func() mp.RedisConn { return pool.Get() }
+//  keyPrefix: 会话在 redis 里的 key 的前缀, 比如 "wechat:session:"
+func NewRedisSessionStore(getConn func() RedisConn, keyPrefix string) *RedisSessionStore {
+	if getConn == nil {
+		panic("nil getConn")
+	}
+	return &RedisSessionStore{
+		getConn:   getConn,
+		keyPrefix: keyPrefix,
+	}
+}
+
+func (store *RedisSessionStore) Get(key string) (data []byte, err error) {
+	conn := store.getConn()
+	defer conn.Close()
+
+	reply, err := conn.Do("GET", store.keyPrefix+key)
+	if err != nil {
+		return
+	}
+	switch v := reply.(type) {
+	case nil:
+		return
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		err = fmt.Errorf("unexpected redis reply type: %T", reply)
+	}
+	return
+}
+
+func (store *RedisSessionStore) Set(key string, data []byte, ttl time.Duration) (err error) {
+	conn := store.getConn()
+	defer conn.Close()
+
+	_, err = conn.Do("SET", store.keyPrefix+key, data, "PX", int64(ttl/time.Millisecond))
+	return
+}
+
+func (store *RedisSessionStore) Delete(key string) (err error) {
+	conn := store.getConn()
+	defer conn.Close()
+
+	_, err = conn.Do("DEL", store.keyPrefix+key)
+	return
+}
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
 	"sync"
 )
 
@@ -24,9 +25,14 @@ import (
 //   MultiServerFrontend.SetServer("1234567890", Server)
 //  来增加一个 Server 来处理 wechat_server=1234567890 的消息(事件).
 //
+//  也可以用 NewPathMultiServerFrontend 创建通过 URL 路径索引 Server 的 MultiServerFrontend, 例如回调 URL 为:
+//    http://www.xxx.com/weixin/1234567890
+//  那么 pathPrefix == "/weixin/", 调用 MultiServerFrontend.SetServer("1234567890", Server) 即可.
+//
 //  MultiServerFrontend 并发安全, 可以在运行中动态增加和删除 Server.
 type MultiServerFrontend struct {
 	urlServerQueryName string
+	pathPrefix         string // 不为空时通过 URL 路径索引 Server, urlServerQueryName 不起作用
 
 	errHandler  ErrorHandler
 	interceptor Interceptor
@@ -55,6 +61,26 @@ func NewMultiServerFrontend(urlServerQueryName string, errHandler ErrorHandler,
 	}
 }
 
+// NewPathMultiServerFrontend 创建一个通过 URL 路径索引 Server 的 MultiServerFrontend.
+//  pathPrefix:  回调 URL 路径的前缀, 路径去掉这个前缀后剩下的部分就是索引 Server 的 key
+//  errHandler:  错误处理 handler, 可以为 nil
+//  interceptor: 拦截器, 可以为 nil
+func NewPathMultiServerFrontend(pathPrefix string, errHandler ErrorHandler, interceptor Interceptor) *MultiServerFrontend {
+	if pathPrefix == "" {
+		pathPrefix = "/"
+	}
+	if errHandler == nil {
+		errHandler = DefaultErrorHandler
+	}
+
+	return &MultiServerFrontend{
+		pathPrefix:  pathPrefix,
+		errHandler:  errHandler,
+		interceptor: interceptor,
+		serverMap:   make(map[string]Server),
+	}
+}
+
 func (frontend *MultiServerFrontend) SetServer(serverKey string, server Server) (err error) {
 	if serverKey == "" {
 		return errors.New("empty serverKey")
@@ -69,6 +95,14 @@ func (frontend *MultiServerFrontend) SetServer(serverKey string, server Server)
 	return
 }
 
+// 获取 serverKey 对应的 Server, 没有找到返回 nil.
+func (frontend *MultiServerFrontend) GetServer(serverKey string) (server Server) {
+	frontend.rwmutex.RLock()
+	server = frontend.serverMap[serverKey]
+	frontend.rwmutex.RUnlock()
+	return
+}
+
 func (frontend *MultiServerFrontend) DeleteServer(serverKey string) {
 	frontend.rwmutex.Lock()
 	delete(frontend.serverMap, serverKey)
@@ -92,19 +126,31 @@ func (frontend *MultiServerFrontend) ServeHTTP(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	serverKey := queryValues.Get(frontend.urlServerQueryName)
-	if serverKey == "" {
-		err := fmt.Errorf("the url query value with name %s is empty", frontend.urlServerQueryName)
-		frontend.errHandler.ServeError(w, r, err)
-		return
+	var serverKey string
+	if frontend.pathPrefix != "" {
+		if !strings.HasPrefix(r.URL.Path, frontend.pathPrefix) {
+			err := fmt.Errorf("the url path %s does not have prefix %s", r.URL.Path, frontend.pathPrefix)
+			frontend.errHandler.ServeError(w, r, err)
+			return
+		}
+		serverKey = strings.Trim(r.URL.Path[len(frontend.pathPrefix):], "/")
+		if serverKey == "" {
+			err := fmt.Errorf("the url path %s has no server key", r.URL.Path)
+			frontend.errHandler.ServeError(w, r, err)
+			return
+		}
+	} else {
+		serverKey = queryValues.Get(frontend.urlServerQueryName)
+		if serverKey == "" {
+			err := fmt.Errorf("the url query value with name %s is empty", frontend.urlServerQueryName)
+			frontend.errHandler.ServeError(w, r, err)
+			return
+		}
 	}
 
-	frontend.rwmutex.RLock()
-	server := frontend.serverMap[serverKey]
-	frontend.rwmutex.RUnlock()
-
+	server := frontend.GetServer(serverKey)
 	if server == nil {
-		err := fmt.Errorf("Not found Server for %s == %s", frontend.urlServerQueryName, serverKey)
+		err := fmt.Errorf("Not found Server for key %s", serverKey)
 		frontend.errHandler.ServeError(w, r, err)
 		return
 	}
@@ -0,0 +1,186 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"mime/multipart"
+)
+
+// 流式的 multipart/form-data 请求体, 边读 MultipartFormField.Value 边写入 http 请求, 不在内存中缓存整个文件.
+//  如果 field 的 Value 不是 io.Seeker, 则先把它读入内存(和以前一样), 这样所有的 Value 都可以重复读取(用于重试),
+//  并且可以预先计算出 Content-Length.
+type multipartBody struct {
+	fields        []MultipartFormField
+	boundary      string
+	offsets       []int64 // 每个 field.Value 的初始位置
+	contentLength int64
+	buffers       []*bytes.Buffer // 缓存不是 io.Seeker 的 Value, close 时放回池里
+
+	pipeReader *io.PipeReader // 上一次 open 返回的 reader
+	done       chan struct{}  // 上一次 open 启动的写 goroutine 结束后关闭
+}
+
+func newMultipartBody(fields []MultipartFormField) (body *multipartBody, err error) {
+	body = &multipartBody{
+		fields:   make([]MultipartFormField, len(fields)),
+		boundary: multipart.NewWriter(nil).Boundary(),
+		offsets:  make([]int64, len(fields)),
+	}
+	copy(body.fields, fields)
+
+	sizes := make([]int64, len(fields))
+	for i := range body.fields {
+		field := &body.fields[i]
+		if field.ContentType != 0 && field.ContentType != 1 {
+			continue
+		}
+		seeker, ok := field.Value.(io.Seeker)
+		if !ok {
+			buf := getBuffer(0)
+			body.buffers = append(body.buffers, buf)
+			if _, err = buf.ReadFrom(field.Value); err != nil {
+				body.close()
+				return nil, err
+			}
+			reader := bytes.NewReader(buf.Bytes())
+			field.Value = reader
+			seeker = reader
+		}
+		if body.offsets[i], err = seeker.Seek(0, io.SeekCurrent); err != nil {
+			body.close()
+			return nil, err
+		}
+		var end int64
+		if end, err = seeker.Seek(0, io.SeekEnd); err != nil {
+			body.close()
+			return nil, err
+		}
+		if _, err = seeker.Seek(body.offsets[i], io.SeekStart); err != nil {
+			body.close()
+			return nil, err
+		}
+		sizes[i] = end - body.offsets[i]
+	}
+
+	// 写一遍不带内容的 multipart, 加上各个 field 的大小就是 Content-Length
+	var counter countWriter
+	multipartWriter := multipart.NewWriter(&counter)
+	if err = multipartWriter.SetBoundary(body.boundary); err != nil {
+		body.close()
+		return nil, err
+	}
+	for i, field := range body.fields {
+		if field.ContentType != 0 && field.ContentType != 1 {
+			continue
+		}
+		if _, err = createMultipartPart(multipartWriter, field); err != nil {
+			body.close()
+			return nil, err
+		}
+		counter.n += sizes[i]
+	}
+	if err = multipartWriter.Close(); err != nil {
+		body.close()
+		return nil, err
+	}
+	body.contentLength = counter.n
+	return body, nil
+}
+
+func (body *multipartBody) contentType() string {
+	return "multipart/form-data; boundary=" + body.boundary
+}
+
+// 请求体的大小, 用于 RequestInfo.RequestBodySize.
+func (body *multipartBody) size() int {
+	return int(body.contentLength)
+}
+
+// 打开一个新的请求体, 用 io.Pipe 在另外一个 goroutine 里写入 multipart 数据.
+//  返回的 io.ReadCloser 交给 http.Client, 由它负责 Close.
+//  重试时会先停止上一次的写 goroutine, 再把各个 Value 定位到初始位置.
+func (body *multipartBody) open() (rc io.ReadCloser, err error) {
+	body.stop()
+	for i, field := range body.fields {
+		if field.ContentType != 0 && field.ContentType != 1 {
+			continue
+		}
+		if _, err = field.Value.(io.Seeker).Seek(body.offsets[i], io.SeekStart); err != nil {
+			return
+		}
+	}
+
+	pipeReader, pipeWriter := io.Pipe()
+	done := make(chan struct{})
+	body.pipeReader = pipeReader
+	body.done = done
+	go func() {
+		defer close(done)
+		pipeWriter.CloseWithError(body.writeTo(pipeWriter))
+	}()
+	return pipeReader, nil
+}
+
+// 停止上一次 open 启动的写 goroutine, 并等待它结束, 之后不会再读取 field.Value.
+func (body *multipartBody) stop() {
+	if body.done == nil {
+		return
+	}
+	body.pipeReader.CloseWithError(errMultipartBodyClosed)
+	<-body.done
+	body.pipeReader = nil
+	body.done = nil
+}
+
+// 请求结束后调用, 停止写 goroutine 并释放缓存.
+func (body *multipartBody) close() {
+	body.stop()
+	for _, buf := range body.buffers {
+		putBuffer(buf)
+	}
+	body.buffers = nil
+}
+
+var errMultipartBodyClosed = errors.New("multipart body closed")
+
+func (body *multipartBody) writeTo(w io.Writer) (err error) {
+	multipartWriter := multipart.NewWriter(w)
+	if err = multipartWriter.SetBoundary(body.boundary); err != nil {
+		return
+	}
+	for _, field := range body.fields {
+		if field.ContentType != 0 && field.ContentType != 1 {
+			continue
+		}
+		partWriter, err := createMultipartPart(multipartWriter, field)
+		if err != nil {
+			return err
+		}
+		if _, err = io.Copy(partWriter, field.Value); err != nil {
+			return err
+		}
+	}
+	return multipartWriter.Close()
+}
+
+func createMultipartPart(multipartWriter *multipart.Writer, field MultipartFormField) (io.Writer, error) {
+	if field.ContentType == 1 { // 文本
+		return multipartWriter.CreateFormField(field.FieldName)
+	}
+	return multipartWriter.CreateFormFile(field.FieldName, field.FileName) // 文件
+}
+
+type countWriter struct {
+	n int64
+}
+
+func (w *countWriter) Write(p []byte) (int, error) {
+	w.n += int64(len(p))
+	return len(p), nil
+}
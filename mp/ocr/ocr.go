@@ -0,0 +1,302 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package ocr
+
+import (
+	"errors"
+	"io"
+	"net/url"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 要识别(处理)的图片, 图片的 URL 或者上传的图片文件, 用 ImageURL 或 ImageFile 创建.
+//  github.com/chanxuehong/wechat/mp/img 的图像处理接口也使用这个类型.
+type Image struct {
+	url      string
+	filename string
+	reader   io.Reader
+}
+
+// 通过图片的 URL 识别, 微信服务器会去下载这个图片.
+func ImageURL(imgURL string) Image {
+	return Image{url: imgURL}
+}
+
+// 通过上传图片文件识别.
+//  filename: 图片的文件名, 比如 idcard.jpg
+func ImageFile(filename string, reader io.Reader) Image {
+	return Image{filename: filename, reader: reader}
+}
+
+// 身份证识别的图片类型
+const (
+	IDCardModePhoto = "photo" // 拍照模式
+	IDCardModeScan  = "scan"  // 扫描模式
+)
+
+// 身份证的正反面
+const (
+	IDCardTypeFront = "Front" // 正面
+	IDCardTypeBack  = "Back"  // 背面
+)
+
+// 身份证识别结果
+type IDCardResult struct {
+	Type        string `json:"type"`        // 正面或背面, IDCardTypeFront, IDCardTypeBack
+	Name        string `json:"name"`        // 姓名, 正面返回
+	Id          string `json:"id"`          // 身份证号, 正面返回
+	Addr        string `json:"addr"`        // 地址, 正面返回
+	Gender      string `json:"gender"`      // 性别, 正面返回
+	Nationality string `json:"nationality"` // 民族, 正面返回
+	ValidDate   string `json:"valid_date"`  // 有效期, 背面返回, 比如 20070105-20270105
+}
+
+// 身份证识别.
+//  mode: 图片类型, IDCardModePhoto 或 IDCardModeScan, 为空时使用 IDCardModePhoto
+func (clt *Client) IDCard(img Image, mode string) (rslt *IDCardResult, err error) {
+	if mode == "" {
+		mode = IDCardModePhoto
+	}
+
+	var result struct {
+		mp.Error
+		IDCardResult
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cv/ocr/idcard?type=" + url.QueryEscape(mode) + "&"
+	if err = clt.PostImage(incompleteURL, img, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	rslt = &result.IDCardResult
+	return
+}
+
+// 银行卡识别.
+func (clt *Client) BankCard(img Image) (number string, err error) {
+	var result struct {
+		mp.Error
+		Number string `json:"number"` // 银行卡号
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cv/ocr/bankcard?"
+	if err = clt.PostImage(incompleteURL, img, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	number = result.Number
+	return
+}
+
+// 坐标
+type Point struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+// 四边形的四个顶点的坐标
+type Position struct {
+	LeftTop     Point `json:"left_top"`
+	RightTop    Point `json:"right_top"`
+	RightBottom Point `json:"right_bottom"`
+	LeftBottom  Point `json:"left_bottom"`
+}
+
+// 图片的大小
+type ImageSize struct {
+	Width  int `json:"w"`
+	Height int `json:"h"`
+}
+
+// 行驶证识别结果
+type DrivingResult struct {
+	PlateNum       string `json:"plate_num"`       // 车牌号码
+	VehicleType    string `json:"vehicle_type"`    // 车辆类型
+	Owner          string `json:"owner"`           // 所有人
+	Addr           string `json:"addr"`            // 住址
+	UseCharacter   string `json:"use_character"`   // 使用性质
+	Model          string `json:"model"`           // 品牌型号
+	Vin            string `json:"vin"`             // 车辆识别代号
+	EngineNum      string `json:"engine_num"`      // 发动机号码
+	RegisterDate   string `json:"register_date"`   // 注册日期
+	IssueDate      string `json:"issue_date"`      // 发证日期
+	PlateNumB      string `json:"plate_num_b"`     // 车牌号码(副页)
+	Record         string `json:"record"`          // 号牌
+	PassengersNum  string `json:"passengers_num"`  // 核定载人数
+	TotalQuality   string `json:"total_quality"`   // 总质量
+	PrepareQuality string `json:"prepare_quality"` // 整备质量
+	OverallSize    string `json:"overall_size"`    // 外廓尺寸
+
+	CardPositionFront Position  `json:"card_position_front"` // 卡片正面位置
+	CardPositionBack  Position  `json:"card_position_back"`  // 卡片反面位置
+	ImageSize         ImageSize `json:"img_size"`            // 图片大小
+}
+
+// 行驶证识别.
+func (clt *Client) Driving(img Image) (rslt *DrivingResult, err error) {
+	var result struct {
+		mp.Error
+		DrivingResult
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cv/ocr/driving?"
+	if err = clt.PostImage(incompleteURL, img, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	rslt = &result.DrivingResult
+	return
+}
+
+// 驾驶证识别结果
+type DrivingLicenseResult struct {
+	IdNum        string `json:"id_num"`        // 证号
+	Name         string `json:"name"`          // 姓名
+	Sex          string `json:"sex"`           // 性别
+	Nationality  string `json:"nationality"`   // 国籍
+	Address      string `json:"address"`       // 住址
+	BirthDate    string `json:"birth_date"`    // 出生日期
+	IssueDate    string `json:"issue_date"`    // 初次领证日期
+	CarClass     string `json:"car_class"`     // 准驾车型
+	ValidFrom    string `json:"valid_from"`    // 有效期限起始日
+	ValidTo      string `json:"valid_to"`      // 有效期限终止日
+	OfficialSeal string `json:"official_seal"` // 印章文字
+}
+
+// 驾驶证识别.
+func (clt *Client) DrivingLicense(img Image) (rslt *DrivingLicenseResult, err error) {
+	var result struct {
+		mp.Error
+		DrivingLicenseResult
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cv/ocr/drivinglicense?"
+	if err = clt.PostImage(incompleteURL, img, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	rslt = &result.DrivingLicenseResult
+	return
+}
+
+// 营业执照识别结果
+type BizLicenseResult struct {
+	RegNum              string `json:"reg_num"`              // 注册号
+	Serial              string `json:"serial"`               // 编号
+	LegalRepresentative string `json:"legal_representative"` // 法定代表人姓名
+	EnterpriseName      string `json:"enterprise_name"`      // 企业名称
+	TypeOfOrganization  string `json:"type_of_organization"` // 组成形式
+	Address             string `json:"address"`              // 经营场所/企业住所
+	TypeOfEnterprise    string `json:"type_of_enterprise"`   // 公司类型
+	BusinessScope       string `json:"business_scope"`       // 经营范围
+	RegisteredCapital   string `json:"registered_capital"`   // 注册资本
+	PaidInCapital       string `json:"paid_in_capital"`      // 实收资本
+	ValidPeriod         string `json:"valid_period"`         // 营业期限
+	RegisteredDate      string `json:"registered_date"`      // 注册日期/成立日期
+	CertPosition        struct {
+		Position Position `json:"pos"`
+	} `json:"cert_position"` // 营业执照位置
+	ImageSize ImageSize `json:"img_size"` // 图片大小
+}
+
+// 营业执照识别.
+func (clt *Client) BizLicense(img Image) (rslt *BizLicenseResult, err error) {
+	var result struct {
+		mp.Error
+		BizLicenseResult
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cv/ocr/bizlicense?"
+	if err = clt.PostImage(incompleteURL, img, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	rslt = &result.BizLicenseResult
+	return
+}
+
+// 通用印刷体识别出的一行文字
+type TextItem struct {
+	Text     string   `json:"text"` // 识别出的文字
+	Position Position `json:"pos"`  // 文字的位置
+}
+
+// 通用印刷体识别结果
+type CommonResult struct {
+	Items     []TextItem `json:"items"`    // 识别出的文字, 按行返回
+	ImageSize ImageSize  `json:"img_size"` // 图片大小
+}
+
+// 通用印刷体识别.
+func (clt *Client) Common(img Image) (rslt *CommonResult, err error) {
+	var result struct {
+		mp.Error
+		CommonResult
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cv/ocr/comm?"
+	if err = clt.PostImage(incompleteURL, img, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	rslt = &result.CommonResult
+	return
+}
+
+// 调用以图片为输入的接口(ocr 和 github.com/chanxuehong/wechat/mp/img 的接口).
+//  incompleteURL 以 '?' 或 '&' 结尾, 后面会加上 img_url(如果有) 和 access_token;
+//  response 的格式要求见 mp.Client.PostJSON.
+func (clt *Client) PostImage(incompleteURL string, img Image, response interface{}) (err error) {
+	switch {
+	case img.url != "":
+		var request struct{}
+		incompleteURL += "img_url=" + url.QueryEscape(img.url) + "&access_token="
+		return ((*mp.Client)(clt)).PostJSON(incompleteURL, &request, response)
+
+	case img.reader != nil:
+		if img.filename == "" {
+			return errors.New("empty filename")
+		}
+		fields := []mp.MultipartFormField{
+			{
+				ContentType: 0,
+				FieldName:   "img",
+				FileName:    img.filename,
+				Value:       img.reader,
+			},
+		}
+		incompleteURL += "access_token="
+		return ((*mp.Client)(clt)).PostMultipartForm(incompleteURL, fields, response)
+
+	default:
+		return errors.New("empty image, use ImageURL or ImageFile")
+	}
+}
@@ -40,8 +40,16 @@ type PoiAddParameters struct {
 
 // 创建门店.
 func (clt *Client) PoiAdd(para *PoiAddParameters) (err error) {
+	_, err = clt.PoiAddEx(para)
+	return
+}
+
+// 创建门店, 返回微信服务器分配的门店id.
+//  poiId 门店审核通过前不可用, 审核结果通过 PoiCheckNotifyEvent 推送.
+func (clt *Client) PoiAddEx(para *PoiAddParameters) (poiId int64, err error) {
 	if para == nil {
-		return errors.New("nil PoiAddParameters")
+		err = errors.New("nil PoiAddParameters")
+		return
 	}
 
 	var request = struct {
@@ -50,7 +58,10 @@ func (clt *Client) PoiAdd(para *PoiAddParameters) (err error) {
 		PoiAddParameters: para,
 	}
 
-	var result mp.Error
+	var result struct {
+		mp.Error
+		PoiId int64 `json:"poi_id"`
+	}
 
 	incompleteURL := "https://api.weixin.qq.com/cgi-bin/poi/addpoi?access_token="
 	if err = ((*mp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
@@ -58,8 +69,9 @@ func (clt *Client) PoiAdd(para *PoiAddParameters) (err error) {
 	}
 
 	if result.ErrCode != mp.ErrCodeOK {
-		err = &result
+		err = &result.Error
 		return
 	}
+	poiId = result.PoiId
 	return
 }
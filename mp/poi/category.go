@@ -9,7 +9,7 @@ import (
 	"github.com/chanxuehong/wechat/mp"
 )
 
-// 查询门店信息.
+// 获取门店类目表.
 func (clt *Client) GetWxCategory() (categoryList []string, err error) {
 	var result struct {
 		mp.Error
@@ -0,0 +1,155 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package prometheus
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 默认的耗时直方图分桶, 单位秒
+var DefaultBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// 没有收到微信服务器响应(网络错误, http 状态码不是 200 等)时 errcode 标签的值
+const ErrCodeLabelHTTPError = "http_error"
+
+var _ mp.MetricsCollector = (*Collector)(nil)
+var _ http.Handler = (*Collector)(nil)
+
+// mp.MetricsCollector 的实现, 同时也是一个 http.Handler, 以 Prometheus 文本格式输出统计数据:
+//
+//  wechat_api_calls_total{family="media"}                    api 调用次数
+//  wechat_api_errors_total{family="media",errcode="40001"}   api 调用出错的次数
+//  wechat_api_latency_seconds{family="media"}                api 调用耗时的直方图
+//
+//  clt.Hook = mp.NewMetricsHook(collector)
+//  http.Handle("/metrics", collector)
+type Collector struct {
+	buckets []float64
+
+	mutex    sync.Mutex
+	families map[string]*familyMetrics
+}
+
+type familyMetrics struct {
+	calls        uint64
+	errors       map[string]uint64 // map[errcode]count
+	bucketCounts []uint64          // 和 Collector.buckets 一一对应, 非累计
+	latencySum   float64
+}
+
+// 创建一个新的 Collector.
+//  buckets: 耗时直方图的分桶上限(单位秒, 升序), 为空则使用 DefaultBuckets
+func NewCollector(buckets []float64) *Collector {
+	if len(buckets) == 0 {
+		buckets = DefaultBuckets
+	}
+	buckets = append([]float64(nil), buckets...)
+	sort.Float64s(buckets)
+
+	return &Collector{
+		buckets:  buckets,
+		families: make(map[string]*familyMetrics),
+	}
+}
+
+func (collector *Collector) ObserveAPICall(family string, info *mp.RequestInfo) {
+	latency := info.Latency.Seconds()
+
+	var errCodeLabel string
+	switch {
+	case info.Err != nil || info.StatusCode != http.StatusOK:
+		errCodeLabel = ErrCodeLabelHTTPError
+	case info.ErrCode != mp.ErrCodeOK:
+		errCodeLabel = strconv.Itoa(info.ErrCode)
+	}
+
+	collector.mutex.Lock()
+	defer collector.mutex.Unlock()
+
+	metrics := collector.families[family]
+	if metrics == nil {
+		metrics = &familyMetrics{
+			errors:       make(map[string]uint64),
+			bucketCounts: make([]uint64, len(collector.buckets)),
+		}
+		collector.families[family] = metrics
+	}
+
+	metrics.calls++
+	if errCodeLabel != "" {
+		metrics.errors[errCodeLabel]++
+	}
+	for i, upperBound := range collector.buckets {
+		if latency <= upperBound {
+			metrics.bucketCounts[i]++
+			break
+		}
+	}
+	metrics.latencySum += latency
+}
+
+func (collector *Collector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	w.Write(collector.Text())
+}
+
+// 以 Prometheus 文本格式输出统计数据.
+func (collector *Collector) Text() []byte {
+	collector.mutex.Lock()
+	defer collector.mutex.Unlock()
+
+	familyNames := make([]string, 0, len(collector.families))
+	for name := range collector.families {
+		familyNames = append(familyNames, name)
+	}
+	sort.Strings(familyNames)
+
+	var buf bytes.Buffer
+
+	buf.WriteString("# HELP wechat_api_calls_total Total number of wechat api calls.\n")
+	buf.WriteString("# TYPE wechat_api_calls_total counter\n")
+	for _, name := range familyNames {
+		fmt.Fprintf(&buf, "wechat_api_calls_total{family=%q} %d\n", name, collector.families[name].calls)
+	}
+
+	buf.WriteString("# HELP wechat_api_errors_total Total number of failed wechat api calls by errcode.\n")
+	buf.WriteString("# TYPE wechat_api_errors_total counter\n")
+	for _, name := range familyNames {
+		metrics := collector.families[name]
+		errCodes := make([]string, 0, len(metrics.errors))
+		for errCode := range metrics.errors {
+			errCodes = append(errCodes, errCode)
+		}
+		sort.Strings(errCodes)
+		for _, errCode := range errCodes {
+			fmt.Fprintf(&buf, "wechat_api_errors_total{family=%q,errcode=%q} %d\n", name, errCode, metrics.errors[errCode])
+		}
+	}
+
+	buf.WriteString("# HELP wechat_api_latency_seconds Latency of wechat api calls in seconds.\n")
+	buf.WriteString("# TYPE wechat_api_latency_seconds histogram\n")
+	for _, name := range familyNames {
+		metrics := collector.families[name]
+		var cumulative uint64
+		for i, upperBound := range collector.buckets {
+			cumulative += metrics.bucketCounts[i]
+			fmt.Fprintf(&buf, "wechat_api_latency_seconds_bucket{family=%q,le=%q} %d\n",
+				name, strconv.FormatFloat(upperBound, 'g', -1, 64), cumulative)
+		}
+		fmt.Fprintf(&buf, "wechat_api_latency_seconds_bucket{family=%q,le=\"+Inf\"} %d\n", name, metrics.calls)
+		fmt.Fprintf(&buf, "wechat_api_latency_seconds_sum{family=%q} %s\n", name, strconv.FormatFloat(metrics.latencySum, 'g', -1, 64))
+		fmt.Fprintf(&buf, "wechat_api_latency_seconds_count{family=%q} %d\n", name, metrics.calls)
+	}
+
+	return buf.Bytes()
+}
@@ -0,0 +1,225 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// api 调用频率限制器.
+//  api 的名称是 URL 的 path 部分, 比如 /cgi-bin/message/custom/send
+type RateLimiter interface {
+	// 调用 api 之前调用, 返回 error 表示不允许调用该 api, Client 会直接返回这个 error.
+	//  实现可以在这里阻塞等待, 直到允许调用为止.
+	Take(api string) error
+
+	// 微信服务器返回 45009(接口调用超过限制) 的时候调用, 表示 api 当天的调用次数已经用完.
+	Exhausted(api string)
+}
+
+// 从 incompleteURL 中获取 api 的名称, 也就是 URL 的 path 部分.
+func apiName(incompleteURL string) string {
+	s := incompleteURL
+	if i := strings.Index(s, "://"); i >= 0 {
+		s = s[i+3:]
+		if i = strings.IndexByte(s, '/'); i >= 0 {
+			s = s[i:]
+		} else {
+			s = "/"
+		}
+	}
+	if i := strings.IndexByte(s, '?'); i >= 0 {
+		s = s[:i]
+	}
+	return s
+}
+
+// 超过调用限制的错误.
+type RateLimitError struct {
+	API string
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("the daily quota of api %s is exhausted", e.API)
+}
+
+var _ RateLimiter = (*DefaultRateLimiter)(nil)
+
+// RateLimiter 的简单实现.
+//  1. 每天的调用次数限制, 和微信服务器一样在北京时间 0 点重置;
+//  2. 令牌桶, 用于平滑调用频率, 没有令牌的时候 Take 会阻塞等待.
+//  没有通过 SetQuota 设置的 api 不做限制.
+type DefaultRateLimiter struct {
+	mutex  sync.Mutex
+	quotas map[string]*apiQuota
+}
+
+func NewDefaultRateLimiter() *DefaultRateLimiter {
+	return &DefaultRateLimiter{
+		quotas: make(map[string]*apiQuota),
+	}
+}
+
+type apiQuota struct {
+	dailyLimit int   // 每天的调用次数限制, <= 0 表示不限制
+	used       int   // 当天已经调用的次数
+	exhausted  bool  // 当天的调用次数是否已经被微信服务器告知用完
+	day        int64 // used, exhausted 对应的日期, 北京时间 0 点开始的天数
+
+	rate       float64 // 令牌桶每秒产生的令牌数, <= 0 表示不使用令牌桶
+	burst      float64 // 令牌桶的容量
+	tokens     float64 // 令牌桶当前的令牌数
+	lastRefill time.Time
+}
+
+// 北京时间 0 点开始的天数
+func quotaDay(t time.Time) int64 {
+	return (t.Unix() + 8*60*60) / (24 * 60 * 60)
+}
+
+// 设置 api 的调用限制.
+//  api:           api 名称, 比如 /cgi-bin/message/custom/send
+//  dailyLimit:    每天的调用次数限制, <= 0 表示不限制
+//  ratePerSecond: 每秒允许的调用次数, <= 0 表示不限制
+//  burst:         令牌桶的容量, 也就是允许的突发调用次数, 如果 <= 0 则等于 1
+func (limiter *DefaultRateLimiter) SetQuota(api string, dailyLimit int, ratePerSecond float64, burst int) {
+	if burst <= 0 {
+		burst = 1
+	}
+
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+
+	quota := limiter.quotas[api]
+	if quota == nil {
+		quota = &apiQuota{
+			day:        quotaDay(time.Now()),
+			tokens:     float64(burst),
+			lastRefill: time.Now(),
+		}
+		limiter.quotas[api] = quota
+	}
+	quota.dailyLimit = dailyLimit
+	quota.rate = ratePerSecond
+	quota.burst = float64(burst)
+	if quota.tokens > quota.burst {
+		quota.tokens = quota.burst
+	}
+}
+
+// 获取 api 当天剩余的调用次数.
+//  如果 api 没有设置每天的调用次数限制, 则 limited == false.
+func (limiter *DefaultRateLimiter) Remaining(api string) (remaining int, limited bool) {
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+
+	quota := limiter.quotas[api]
+	if quota == nil {
+		return
+	}
+	quota.resetDaily(time.Now())
+
+	if quota.exhausted {
+		limited = true
+		return
+	}
+	if quota.dailyLimit <= 0 {
+		return
+	}
+	limited = true
+	if remaining = quota.dailyLimit - quota.used; remaining < 0 {
+		remaining = 0
+	}
+	return
+}
+
+func (quota *apiQuota) resetDaily(now time.Time) {
+	if day := quotaDay(now); day != quota.day {
+		quota.day = day
+		quota.used = 0
+		quota.exhausted = false
+	}
+}
+
+func (limiter *DefaultRateLimiter) Take(api string) error {
+	for {
+		wait, err := limiter.take(api)
+		if err != nil {
+			return err
+		}
+		if wait <= 0 {
+			return nil
+		}
+		time.Sleep(wait)
+	}
+}
+
+// 如果没有令牌返回需要等待的时间.
+func (limiter *DefaultRateLimiter) take(api string) (wait time.Duration, err error) {
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+
+	quota := limiter.quotas[api]
+	if quota == nil {
+		return
+	}
+
+	now := time.Now()
+	quota.resetDaily(now)
+	if quota.exhausted || (quota.dailyLimit > 0 && quota.used >= quota.dailyLimit) {
+		err = &RateLimitError{API: api}
+		return
+	}
+
+	if quota.rate > 0 {
+		quota.tokens += now.Sub(quota.lastRefill).Seconds() * quota.rate
+		if quota.tokens > quota.burst {
+			quota.tokens = quota.burst
+		}
+		quota.lastRefill = now
+
+		if quota.tokens < 1 {
+			wait = time.Duration((1 - quota.tokens) / quota.rate * float64(time.Second))
+			return
+		}
+		quota.tokens--
+	}
+
+	quota.used++
+	return
+}
+
+// 清零所有 api 当天已经调用的次数, 一般在 Client.ClearQuota 成功后由 Client 调用.
+func (limiter *DefaultRateLimiter) ResetAll() {
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+
+	for _, quota := range limiter.quotas {
+		quota.used = 0
+		quota.exhausted = false
+	}
+}
+
+func (limiter *DefaultRateLimiter) Exhausted(api string) {
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+
+	now := time.Now()
+	quota := limiter.quotas[api]
+	if quota == nil {
+		// 没有设置限制的 api 也记录下来, 当天不再调用
+		quota = &apiQuota{
+			day:        quotaDay(now),
+			lastRefill: now,
+		}
+		limiter.quotas[api] = quota
+	}
+	quota.resetDaily(now)
+	quota.exhausted = true
+}
@@ -0,0 +1,178 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package semantic
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+const (
+	CategoryFlight     = "flight"     // 航班
+	CategoryHotel      = "hotel"      // 酒店
+	CategoryRestaurant = "restaurant" // 餐馆
+	CategoryTrain      = "train"      // 火车
+	CategoryTravel     = "travel"     // 旅游
+	CategoryMovie      = "movie"      // 电影
+	CategoryMusic      = "music"      // 音乐
+	CategoryWeather    = "weather"    // 天气
+	CategoryStock      = "stock"      // 股票
+	CategoryMap        = "map"        // 地图
+	CategoryNearby     = "nearby"     // 周边
+)
+
+type SearchQuery struct {
+	Query     string  `json:"query"`               // 必须; 输入文本串
+	Category  string  `json:"category"`            // 必须; 需要使用的服务类型, 多个用 "," 隔开, 不能为空
+	Latitude  float64 `json:"latitude,omitempty"`  // 非必须; 纬度坐标, 与经度同时传入; 与城市二选一传入
+	Longitude float64 `json:"longitude,omitempty"` // 非必须; 经度坐标, 与纬度同时传入; 与城市二选一传入
+	City      string  `json:"city,omitempty"`      // 非必须; 城市名称, 与经纬度二选一传入
+	Region    string  `json:"region,omitempty"`    // 非必须; 区域名称, 在城市存在的情况下可省; 与经纬度二选一传入
+	AppId     string  `json:"appid"`               // 必须; 公众号唯一标识, 用于区分公众号开发者
+	UId       string  `json:"uid,omitempty"`       // 非必须; 用户唯一id(非开发者id), 用户区分公众号下的不同用户(建议填入用户openid), 如果为空, 则无法使用上下文理解功能
+}
+
+type SearchResult struct {
+	Query    string `json:"query"` // 用户的输入字符串
+	Type     string `json:"type"`  // 服务的全局类型id, 详见协议文档中垂直服务协议定义
+	Semantic struct {
+		Intent  string          `json:"intent"`  // 服务的意图
+		Details json.RawMessage `json:"details"` // 详细信息里面包含具体的语义结果, 根据 Type 解析, 参考 FlightDetails, HotelDetails, RestaurantDetails
+	} `json:"semantic"`
+	Result  json.RawMessage `json:"result,omitempty"`  // 统一上下文返回的结果
+	Answer  string          `json:"answer,omitempty"`  // 部分类别的结果
+	Text    string          `json:"text,omitempty"`    // 部分类别的结果html5展示, 目前不支持
+	Context json.RawMessage `json:"context,omitempty"` // 上下文信息
+}
+
+// 语义理解.
+func (clt *Client) SemanticSearch(query *SearchQuery) (rslt *SearchResult, err error) {
+	if query == nil {
+		err = errors.New("nil query")
+		return
+	}
+	if query.Query == "" {
+		err = errors.New("empty query.Query")
+		return
+	}
+	if query.Category == "" {
+		err = errors.New("empty query.Category")
+		return
+	}
+
+	var result struct {
+		mp.Error
+		SearchResult
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/semantic/semproxy/search?access_token="
+	if err = ((*mp.Client)(clt)).PostJSON(incompleteURL, query, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	rslt = &result.SearchResult
+	return
+}
+
+// 地点
+type Location struct {
+	Type       string `json:"type"`                  // LOC_COUNTRY, LOC_PROVINCE, LOC_CITY, LOC_TOWN, LOC_POI, NORMAL_POI
+	Country    string `json:"country,omitempty"`     // 国家
+	Province   string `json:"province,omitempty"`    // 省全称, 例如: 广东省
+	City       string `json:"city,omitempty"`        // 市全称, 例如: 北京市
+	CitySimple string `json:"city_simple,omitempty"` // 市简称与别名, 多个用 "," 隔开, 例如: 北京,京
+	Town       string `json:"town,omitempty"`        // 县区全称, 例如: 海淀区
+	TownSimple string `json:"town_simple,omitempty"` // 县区简称与别名, 多个用 "," 隔开, 例如: 海淀
+	Poi        string `json:"poi,omitempty"`         // poi 详细地址
+	LocOri     string `json:"loc_ori"`               // 用户语义中的原始地址串
+}
+
+// 时间
+type DateTime struct {
+	Type    string `json:"type"`               // DT_ORI, DT_INFINITE, DT_SINGLE, DT_INTERVAL, DT_REPEAT 等
+	Date    string `json:"date,omitempty"`     // 单个日期, 格式 YYYY-MM-DD
+	DateOri string `json:"date_ori,omitempty"` // 用户语义中的原始日期串
+	Week    string `json:"week,omitempty"`     // 星期, 1-7 分别表示周一到周日
+	Time    string `json:"time,omitempty"`     // 时间, 格式 HH:MM:SS
+	TimeOri string `json:"time_ori,omitempty"` // 用户语义中的原始时间串
+}
+
+// 航班服务(flight)的语义结果
+type FlightDetails struct {
+	StartLoc  Location `json:"start_loc"`           // 起点
+	EndLoc    Location `json:"end_loc"`             // 终点
+	StartDate DateTime `json:"start_date"`          // 出发日期
+	EndDate   DateTime `json:"end_date"`            // 返回日期
+	Airline   string   `json:"airline,omitempty"`   // 航空公司
+	FlightNo  string   `json:"flight_no,omitempty"` // 航班号
+	Seat      string   `json:"seat,omitempty"`      // 舱位
+	Sort      int      `json:"sort,omitempty"`      // 排序类型: 0 排序无要求(默认), 1 价格升序, 2 价格降序, 3 时间升序, 4 时间降序
+}
+
+// 酒店服务(hotel)的语义结果
+type HotelDetails struct {
+	Location  Location `json:"location"`        // 酒店地址
+	StartDate DateTime `json:"start_date"`      // 入住日期
+	EndDate   DateTime `json:"end_date"`        // 离店日期
+	Name      string   `json:"name,omitempty"`  // 酒店名称
+	Brand     string   `json:"brand,omitempty"` // 酒店品牌
+	Star      string   `json:"star,omitempty"`  // 酒店星级
+	Sort      int      `json:"sort,omitempty"`  // 排序类型: 0 排序无要求(默认), 1 价格升序, 2 价格降序, 3 距离升序
+}
+
+// 餐馆服务(restaurant)的语义结果
+type RestaurantDetails struct {
+	Location Location `json:"location"`           // 餐馆地址
+	Name     string   `json:"name,omitempty"`     // 餐馆名称
+	Category string   `json:"category,omitempty"` // 菜系, 例如: 川菜
+	Special  string   `json:"special,omitempty"`  // 特色菜, 例如: 回锅肉
+	Price    string   `json:"price,omitempty"`    // 价格
+	Sort     int      `json:"sort,omitempty"`     // 排序类型: 0 排序无要求(默认), 1 价格升序, 2 价格降序, 3 距离升序, 4 评价降序
+}
+
+// 解析航班服务的语义结果, rslt.Type 必须是 CategoryFlight.
+func (rslt *SearchResult) FlightDetails() (details *FlightDetails, err error) {
+	details = new(FlightDetails)
+	if err = rslt.unmarshalDetails(CategoryFlight, details); err != nil {
+		details = nil
+	}
+	return
+}
+
+// 解析酒店服务的语义结果, rslt.Type 必须是 CategoryHotel.
+func (rslt *SearchResult) HotelDetails() (details *HotelDetails, err error) {
+	details = new(HotelDetails)
+	if err = rslt.unmarshalDetails(CategoryHotel, details); err != nil {
+		details = nil
+	}
+	return
+}
+
+// 解析餐馆服务的语义结果, rslt.Type 必须是 CategoryRestaurant.
+func (rslt *SearchResult) RestaurantDetails() (details *RestaurantDetails, err error) {
+	details = new(RestaurantDetails)
+	if err = rslt.unmarshalDetails(CategoryRestaurant, details); err != nil {
+		details = nil
+	}
+	return
+}
+
+func (rslt *SearchResult) unmarshalDetails(category string, details interface{}) error {
+	if rslt.Type != category {
+		return fmt.Errorf("the type of result is %s, not %s", rslt.Type, category)
+	}
+	if len(rslt.Semantic.Details) == 0 {
+		return errors.New("empty semantic.details")
+	}
+	return json.Unmarshal(rslt.Semantic.Details, details)
+}
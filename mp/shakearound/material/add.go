@@ -15,10 +15,17 @@ import (
 	"github.com/chanxuehong/wechat/mp"
 )
 
+const (
+	TypeIcon    = "icon"    // 摇一摇页面展示的icon图, 图片格式限定为: jpg, jpeg, png, gif; 图片大小建议120*120, 限制不超过200*200, 不超过200KB
+	TypeLicense = "license" // 申请开通摇一摇周边功能时需上传的资质文件, 图片大小不超过2MB
+)
+
 type ImageInfo struct {
 	PicURL string `json:"pic_url"`
 }
 
+// 上传图片素材.
+//  _type: TypeIcon, TypeLicense, 为空时默认为 TypeIcon
 func Add(clt *mp.Client, imagePath, _type string) (info ImageInfo, err error) {
 	file, err := os.Open(imagePath)
 	if err != nil {
@@ -29,6 +36,8 @@ func Add(clt *mp.Client, imagePath, _type string) (info ImageInfo, err error) {
 	return addFromReader(clt, filepath.Base(imagePath), file, _type)
 }
 
+// 上传图片素材.
+//  _type: TypeIcon, TypeLicense, 为空时默认为 TypeIcon
 func AddFromReader(clt *mp.Client, filename string, reader io.Reader, _type string) (info ImageInfo, err error) {
 	if filename == "" {
 		err = errors.New("empty filename")
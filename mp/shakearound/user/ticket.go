@@ -0,0 +1,25 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package user
+
+import (
+	"errors"
+	"net/url"
+)
+
+// 从摇到的页面 URL 中获取摇周边业务的 ticket, 用于 GetShakeInfo.
+//  用户摇到的页面 URL 会带上 ticket 参数, 比如 http://www.example.com/shake?ticket=6ab3d8465166598a5f4e8c1b44f44645
+func TicketFromURL(rawurl string) (ticket string, err error) {
+	u, err := url.Parse(rawurl)
+	if err != nil {
+		return
+	}
+	if ticket = u.Query().Get("ticket"); ticket == "" {
+		err = errors.New("ticket not found in url")
+		return
+	}
+	return
+}
@@ -0,0 +1,86 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/chanxuehong/util/security"
+
+	"github.com/chanxuehong/wechat/util"
+)
+
+// 校验明文模式消息和 URL 认证请求的签名, 即回调 URL 里的 signature 参数.
+func ValidateSignature(token, timestamp, nonce, signature string) bool {
+	return security.SecureCompareString(signature, util.Sign(token, timestamp, nonce))
+}
+
+// 校验安全模式消息的签名, 即回调 URL 里的 msg_signature 参数.
+func ValidateMsgSignature(token, timestamp, nonce, encryptedMsg, msgSignature string) bool {
+	return security.SecureCompareString(msgSignature, util.MsgSign(token, timestamp, nonce, encryptedMsg))
+}
+
+var _ http.Handler = (*URLVerifyHandler)(nil)
+
+// 处理微信服务器验证回调 URL 有效性的 GET 请求, 签名正确则原样返回 echostr.
+//  只需要完成 URL 认证的时候可以直接使用, 一般情况下 ServerFrontend 已经处理了这个请求.
+type URLVerifyHandler struct {
+	token      string
+	errHandler ErrorHandler
+}
+
+// 创建一个新的 URLVerifyHandler.
+//  如果 errHandler == nil 则使用 DefaultErrorHandler.
+func NewURLVerifyHandler(token string, errHandler ErrorHandler) *URLVerifyHandler {
+	if token == "" {
+		panic("empty token")
+	}
+	if errHandler == nil {
+		errHandler = DefaultErrorHandler
+	}
+	return &URLVerifyHandler{
+		token:      token,
+		errHandler: errHandler,
+	}
+}
+
+func (handler *URLVerifyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		handler.errHandler.ServeError(w, r, errors.New("method not allowed: "+r.Method))
+		return
+	}
+
+	queryValues := r.URL.Query()
+
+	signature := queryValues.Get("signature")
+	if signature == "" {
+		handler.errHandler.ServeError(w, r, errors.New("signature is empty"))
+		return
+	}
+	timestamp := queryValues.Get("timestamp")
+	if timestamp == "" {
+		handler.errHandler.ServeError(w, r, errors.New("timestamp is empty"))
+		return
+	}
+	nonce := queryValues.Get("nonce")
+	if nonce == "" {
+		handler.errHandler.ServeError(w, r, errors.New("nonce is empty"))
+		return
+	}
+	echostr := queryValues.Get("echostr")
+	if echostr == "" {
+		handler.errHandler.ServeError(w, r, errors.New("echostr is empty"))
+		return
+	}
+
+	if !ValidateSignature(handler.token, timestamp, nonce, signature) {
+		handler.errHandler.ServeError(w, r, errors.New("check signature failed"))
+		return
+	}
+	io.WriteString(w, echostr)
+}
@@ -0,0 +1,47 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"sync"
+)
+
+// 合并并发的调用: 同一时刻只有一个 fn 在执行, 执行期间其他调用者等待并共享它的结果(包括 error).
+//  用于刷新 access_token, 防止 access_token 过期的时候大量 goroutine 同时到微信服务器刷新.
+type singleflight struct {
+	mutex sync.Mutex
+	call  *singleflightCall // 正在执行的调用, nil 表示没有
+}
+
+type singleflightCall struct {
+	done  chan struct{}
+	value interface{}
+	err   error
+}
+
+func (group *singleflight) Do(fn func() (interface{}, error)) (value interface{}, err error) {
+	group.mutex.Lock()
+	if call := group.call; call != nil {
+		group.mutex.Unlock()
+		<-call.done
+		return call.value, call.err
+	}
+	call := &singleflightCall{
+		done: make(chan struct{}),
+	}
+	group.call = call
+	group.mutex.Unlock()
+
+	defer func() {
+		group.mutex.Lock()
+		group.call = nil
+		group.mutex.Unlock()
+		close(call.done)
+	}()
+
+	call.value, call.err = fn()
+	return call.value, call.err
+}
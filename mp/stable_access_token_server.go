@@ -0,0 +1,191 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+var _ AccessTokenServer = (*StableAccessTokenServer)(nil)
+
+// 使用稳定版接口(cgi-bin/stable_token)获取 access_token 的 AccessTokenServer.
+//  和普通的 cgi-bin/token 接口不同, 稳定版接口在普通模式(force_refresh == false)下, access_token 有效期内
+//  重复调用返回的是同一个 access_token, 不会让其他进程(机器)手里的 access_token 失效, 所以多个进程各自
+//  使用一个 StableAccessTokenServer 也没有问题.
+//
+//  forceRefresh 只影响 TokenRefresh(access_token 被微信服务器判定为无效时调用):
+//  1. false: 普通模式, 如果微信服务器上的 access_token 还有效则返回它, 一般用这个模式;
+//  2. true:  强制刷新模式, 微信服务器会生成新的 access_token, 之前获取的 access_token 会失效,
+//     这个模式每天的调用次数有限制(20次), 并且两次调用至少间隔30秒, 只在确认 access_token 泄露等情况下使用.
+type StableAccessTokenServer struct {
+	appId        string
+	appSecret    string
+	forceRefresh bool
+	httpClient   *http.Client
+
+	tokenGet      sync.Mutex   // 同一时刻只能一个 goroutine 到微信服务器获取 access_token
+	refreshFlight singleflight // 合并并发的 TokenRefresh
+	lastGetTime   int64        // 最后一次"成功"从微信服务器获取 access_token 的时间戳
+
+	tokenCache struct {
+		sync.RWMutex
+		Token     string
+		ExpiresAt int64
+	}
+}
+
+// 创建一个新的 StableAccessTokenServer.
+//  如果 clt == nil 则默认使用 http.DefaultClient.
+func NewStableAccessTokenServer(appId, appSecret string, forceRefresh bool, clt *http.Client) *StableAccessTokenServer {
+	if clt == nil {
+		clt = http.DefaultClient
+	}
+
+	return &StableAccessTokenServer{
+		appId:        appId,
+		appSecret:    appSecret,
+		forceRefresh: forceRefresh,
+		httpClient:   clt,
+	}
+}
+
+func (srv *StableAccessTokenServer) TagCE90001AFE9C11E48611A4DB30FED8E1() {}
+
+func (srv *StableAccessTokenServer) Token() (token string, err error) {
+	srv.tokenCache.RLock()
+	token = srv.tokenCache.Token
+	expiresAt := srv.tokenCache.ExpiresAt
+	srv.tokenCache.RUnlock()
+
+	if token != "" && time.Now().Unix() < expiresAt {
+		return
+	}
+	return srv.refresh(false)
+}
+
+func (srv *StableAccessTokenServer) TokenRefresh() (token string, err error) {
+	return srv.refresh(srv.forceRefresh)
+}
+
+// 合并并发的刷新请求, 正在刷新的时候其他 goroutine 等待并共享刷新的结果.
+func (srv *StableAccessTokenServer) refresh(forceRefresh bool) (token string, err error) {
+	value, err := srv.refreshFlight.Do(func() (interface{}, error) {
+		return srv.getToken(forceRefresh)
+	})
+	if err != nil {
+		return
+	}
+	token = value.(string)
+	return
+}
+
+func (srv *StableAccessTokenServer) getToken(forceRefresh bool) (token string, err error) {
+	srv.tokenGet.Lock()
+	defer srv.tokenGet.Unlock()
+
+	timeNowUnix := time.Now().Unix()
+
+	// 在收敛周期(2秒)内直接返回最近一次获取的 access_token
+	if n := srv.lastGetTime; n <= timeNowUnix && timeNowUnix < n+2 {
+		srv.tokenCache.RLock()
+		token = srv.tokenCache.Token
+		srv.tokenCache.RUnlock()
+
+		if token != "" {
+			return
+		}
+	}
+
+	token, expiresIn, err := srv.getTokenFromWechat(forceRefresh)
+	if err != nil {
+		srv.setCache("", 0)
+		return
+	}
+
+	srv.lastGetTime = timeNowUnix
+	srv.setCache(token, timeNowUnix+expiresIn)
+	return
+}
+
+func (srv *StableAccessTokenServer) setCache(token string, expiresAt int64) {
+	srv.tokenCache.Lock()
+	srv.tokenCache.Token = token
+	srv.tokenCache.ExpiresAt = expiresAt
+	srv.tokenCache.Unlock()
+}
+
+// 从微信服务器获取 access_token, 返回 access_token 和(留了缓冲区的)有效时间.
+func (srv *StableAccessTokenServer) getTokenFromWechat(forceRefresh bool) (token string, expiresIn int64, err error) {
+	var request = struct {
+		GrantType    string `json:"grant_type"`
+		AppId        string `json:"appid"`
+		Secret       string `json:"secret"`
+		ForceRefresh bool   `json:"force_refresh"`
+	}{
+		GrantType:    "client_credential",
+		AppId:        srv.appId,
+		Secret:       srv.appSecret,
+		ForceRefresh: forceRefresh,
+	}
+	requestBytes, err := json.Marshal(&request)
+	if err != nil {
+		return
+	}
+
+	httpResp, err := srv.httpClient.Post("https://api.weixin.qq.com/cgi-bin/stable_token",
+		"application/json; charset=utf-8", bytes.NewReader(requestBytes))
+	if err != nil {
+		return
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		err = fmt.Errorf("http.Status: %s", httpResp.Status)
+		return
+	}
+
+	var result struct {
+		Error
+		accessTokenInfo
+	}
+	if err = json.NewDecoder(httpResp.Body).Decode(&result); err != nil {
+		return
+	}
+
+	if result.ErrCode != ErrCodeOK {
+		err = &result.Error
+		return
+	}
+
+	// 由于网络的延时, access_token 过期时间留了一个缓冲区
+	switch {
+	case result.ExpiresIn > 31556952: // 60*60*24*365.2425
+		err = errors.New("expires_in too large: " + strconv.FormatInt(result.ExpiresIn, 10))
+		return
+	case result.ExpiresIn > 60*60:
+		result.ExpiresIn -= 60 * 10
+	case result.ExpiresIn > 60*30:
+		result.ExpiresIn -= 60 * 5
+	case result.ExpiresIn > 60*5:
+		result.ExpiresIn -= 60
+	case result.ExpiresIn > 60:
+		result.ExpiresIn -= 10
+	default:
+		err = errors.New("expires_in too small: " + strconv.FormatInt(result.ExpiresIn, 10))
+		return
+	}
+
+	token = result.Token
+	expiresIn = result.ExpiresIn
+	return
+}
@@ -7,11 +7,17 @@ package user
 
 import (
 	"errors"
+	"fmt"
+	"unicode/utf8"
 
 	"github.com/chanxuehong/wechat/mp"
 )
 
-const GroupCountLimit = 100 // 一个公众账号, 最多支持创建100个分组
+const (
+	GroupCountLimit         = 100 // 一个公众账号, 最多支持创建100个分组
+	GroupNameLengthLimit    = 30  // 分组名字的长度限制, 字符数
+	BatchMoveUserCountLimit = 50  // 批量移动用户分组每次最多移动的用户数
+)
 
 type Group struct {
 	Id        int64  `json:"id"`    // 分组id, 由微信分配
@@ -26,6 +32,10 @@ func (clt *Client) GroupCreate(name string) (group *Group, err error) {
 		err = errors.New("empty name")
 		return
 	}
+	if n := utf8.RuneCountInString(name); n > GroupNameLengthLimit {
+		err = fmt.Errorf("分组名字的长度不能超过 %d 个字符, 现在为 %d", GroupNameLengthLimit, n)
+		return
+	}
 
 	var request struct {
 		Group struct {
@@ -84,6 +94,10 @@ func (clt *Client) GroupUpdate(groupId int64, newName string) (err error) {
 		err = errors.New("empty newName")
 		return
 	}
+	if n := utf8.RuneCountInString(newName); n > GroupNameLengthLimit {
+		err = fmt.Errorf("分组名字的长度不能超过 %d 个字符, 现在为 %d", GroupNameLengthLimit, n)
+		return
+	}
 
 	var request struct {
 		Group struct {
@@ -179,10 +193,15 @@ func (clt *Client) MoveUserToGroup(openId string, toGroupId int64) (err error) {
 }
 
 // 批量移动用户分组.
+//  openIdList 的长度不能超过 BatchMoveUserCountLimit.
 func (clt *Client) BatchMoveUserToGroup(openIdList []string, toGroupId int64) (err error) {
 	if len(openIdList) <= 0 {
 		return
 	}
+	if len(openIdList) > BatchMoveUserCountLimit {
+		err = fmt.Errorf("批量移动的用户数不能超过 %d, 现在为 %d", BatchMoveUserCountLimit, len(openIdList))
+		return
+	}
 
 	var request = struct {
 		OpenIdList []string `json:"openid_list,omitempty"`
@@ -10,6 +10,11 @@ import (
 	"strings"
 )
 
+const (
+	ScopeBase     = "snsapi_base"     // 不弹出授权页面, 直接跳转, 只能获取用户 openid
+	ScopeUserInfo = "snsapi_userinfo" // 弹出授权页面, 可通过 openid 拿到昵称, 性别, 所在地
+)
+
 type Config interface {
 	AuthCodeURL(state string, redirectURIExt url.Values) string // 请求用户授权的地址, 获取code; redirectURIExt 用于扩展回调地址的参数
 	ExchangeTokenURL(code string) string                        // 通过code换取access_token的地址
@@ -28,7 +33,7 @@ type OAuth2Config struct {
 	// 用户禁止授权跳转到 RedirectURI?state=STATE
 	RedirectURI string
 
-	// 应用授权作用域, snsapi_base, snsapi_userinfo
+	// 应用授权作用域, ScopeBase, ScopeUserInfo
 	Scopes []string
 }
 
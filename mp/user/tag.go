@@ -0,0 +1,324 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package user
+
+import (
+	"errors"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+const (
+	TagCountLimit          = 100 // 一个公众号, 最多可以创建100个标签
+	TagNameLengthLimit     = 30  // 标签名的长度限制, 字符数
+	BatchTagUserCountLimit = 50  // 批量为用户打标签(取消标签)每次最多的用户数
+)
+
+type Tag struct {
+	Id        int64  `json:"id"`    // 标签id, 由微信分配
+	Name      string `json:"name"`  // 标签名, UTF8编码
+	UserCount int    `json:"count"` // 此标签下粉丝数
+}
+
+// 创建标签.
+//  name: 标签名(30个字符以内)
+func (clt *Client) TagCreate(name string) (tag *Tag, err error) {
+	if name == "" {
+		err = errors.New("empty name")
+		return
+	}
+	if n := utf8.RuneCountInString(name); n > TagNameLengthLimit {
+		err = fmt.Errorf("标签名的长度不能超过 %d 个字符, 现在为 %d", TagNameLengthLimit, n)
+		return
+	}
+
+	var request struct {
+		Tag struct {
+			Name string `json:"name"`
+		} `json:"tag"`
+	}
+	request.Tag.Name = name
+
+	var result struct {
+		mp.Error
+		Tag `json:"tag"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/tags/create?access_token="
+	if err = ((*mp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	result.Tag.UserCount = 0
+	tag = &result.Tag
+	return
+}
+
+// 获取公众号已创建的标签.
+func (clt *Client) TagList() (tags []Tag, err error) {
+	var result struct {
+		mp.Error
+		Tags []Tag `json:"tags"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/tags/get?access_token="
+	if err = ((*mp.Client)(clt)).GetJSON(incompleteURL, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	tags = result.Tags
+	return
+}
+
+// 编辑标签.
+//  newName: 标签名(30个字符以内).
+func (clt *Client) TagUpdate(tagId int64, newName string) (err error) {
+	if newName == "" {
+		err = errors.New("empty newName")
+		return
+	}
+	if n := utf8.RuneCountInString(newName); n > TagNameLengthLimit {
+		err = fmt.Errorf("标签名的长度不能超过 %d 个字符, 现在为 %d", TagNameLengthLimit, n)
+		return
+	}
+
+	var request struct {
+		Tag struct {
+			Id   int64  `json:"id"`
+			Name string `json:"name"`
+		} `json:"tag"`
+	}
+	request.Tag.Id = tagId
+	request.Tag.Name = newName
+
+	var result mp.Error
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/tags/update?access_token="
+	if err = ((*mp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
+
+// 删除标签.
+//  请注意, 当某个标签下的粉丝超过10w时, 后台不可直接删除标签, 需要先取消粉丝的该标签.
+func (clt *Client) TagDelete(tagId int64) (err error) {
+	var request struct {
+		Tag struct {
+			Id int64 `json:"id"`
+		} `json:"tag"`
+	}
+	request.Tag.Id = tagId
+
+	var result mp.Error
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/tags/delete?access_token="
+	if err = ((*mp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
+
+// 批量为用户打标签.
+//  openIdList 的长度不能超过 BatchTagUserCountLimit.
+func (clt *Client) BatchTagUsers(openIdList []string, tagId int64) (err error) {
+	return clt.batchTagging("https://api.weixin.qq.com/cgi-bin/tags/members/batchtagging?access_token=", openIdList, tagId)
+}
+
+// 批量为用户取消标签.
+//  openIdList 的长度不能超过 BatchTagUserCountLimit.
+func (clt *Client) BatchUntagUsers(openIdList []string, tagId int64) (err error) {
+	return clt.batchTagging("https://api.weixin.qq.com/cgi-bin/tags/members/batchuntagging?access_token=", openIdList, tagId)
+}
+
+func (clt *Client) batchTagging(incompleteURL string, openIdList []string, tagId int64) (err error) {
+	if len(openIdList) <= 0 {
+		return
+	}
+	if len(openIdList) > BatchTagUserCountLimit {
+		err = fmt.Errorf("批量操作的用户数不能超过 %d, 现在为 %d", BatchTagUserCountLimit, len(openIdList))
+		return
+	}
+
+	var request = struct {
+		OpenIdList []string `json:"openid_list,omitempty"`
+		TagId      int64    `json:"tagid"`
+	}{
+		OpenIdList: openIdList,
+		TagId:      tagId,
+	}
+
+	var result mp.Error
+
+	if err = ((*mp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
+
+// 获取用户身上的标签列表.
+func (clt *Client) UserTags(openId string) (tagIdList []int64, err error) {
+	if openId == "" {
+		err = errors.New("empty openId")
+		return
+	}
+
+	var request = struct {
+		OpenId string `json:"openid"`
+	}{
+		OpenId: openId,
+	}
+
+	var result struct {
+		mp.Error
+		TagIdList []int64 `json:"tagid_list"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/tags/getidlist?access_token="
+	if err = ((*mp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	tagIdList = result.TagIdList
+	return
+}
+
+// 获取标签下粉丝列表返回的数据结构
+type TagUserListResult struct {
+	GotCount int `json:"count"` // 这次获取的粉丝数量
+
+	Data struct {
+		OpenIdList []string `json:"openid,omitempty"`
+	} `json:"data"` // 粉丝列表
+
+	// 拉取列表最后一个用户的openid, 如果 next_openid == "" 则表示没有了用户数据
+	NextOpenId string `json:"next_openid"`
+}
+
+// 获取标签下粉丝列表.
+//  每次最多能获取 10000 个用户, 可以多次指定 NextOpenId 来获取以满足需求, 如果 NextOpenId == "" 则表示从头获取
+func (clt *Client) TagUserList(tagId int64, NextOpenId string) (rslt *TagUserListResult, err error) {
+	var request = struct {
+		TagId      int64  `json:"tagid"`
+		NextOpenId string `json:"next_openid"`
+	}{
+		TagId:      tagId,
+		NextOpenId: NextOpenId,
+	}
+
+	var result struct {
+		mp.Error
+		TagUserListResult
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/user/tag/get?access_token="
+	if err = ((*mp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	rslt = &result.TagUserListResult
+	return
+}
+
+// TagUserIterator
+//
+//  iter, err := Client.TagUserIterator(tagId, "NextOpenId")
+//  if err != nil {
+//      // TODO: 增加你的代码
+//  }
+//
+//  for iter.HasNext() {
+//      openids, err := iter.NextPage()
+//      if err != nil {
+//          // TODO: 增加你的代码
+//      }
+//      // TODO: 增加你的代码
+//  }
+type TagUserIterator struct {
+	clt   *Client // 关联的微信 Client
+	tagId int64
+
+	lastTagUserListData *TagUserListResult // 最近一次获取的用户数据
+	nextPageHasCalled   bool               // NextPage() 是否调用过
+}
+
+func (iter *TagUserIterator) HasNext() bool {
+	if !iter.nextPageHasCalled { // 第一次调用需要特殊对待
+		return iter.lastTagUserListData.GotCount > 0 ||
+			iter.lastTagUserListData.NextOpenId != ""
+	}
+	return iter.lastTagUserListData.NextOpenId != ""
+}
+
+func (iter *TagUserIterator) NextPage() (OpenIdList []string, err error) {
+	if !iter.nextPageHasCalled { // 第一次调用需要特殊对待
+		iter.nextPageHasCalled = true
+
+		OpenIdList = iter.lastTagUserListData.Data.OpenIdList
+		return
+	}
+
+	data, err := iter.clt.TagUserList(iter.tagId, iter.lastTagUserListData.NextOpenId)
+	if err != nil {
+		return
+	}
+
+	iter.lastTagUserListData = data
+
+	OpenIdList = data.Data.OpenIdList
+	return
+}
+
+// 获取标签下粉丝的遍历器, 从 NextOpenId 开始遍历, 如果 NextOpenId == "" 则表示从头遍历.
+func (clt *Client) TagUserIterator(tagId int64, NextOpenId string) (iter *TagUserIterator, err error) {
+	// 逻辑上相当于第一次调用 TagUserIterator.NextPage, 因为第一次调用 TagUserIterator.HasNext 需要数据支撑, 所以提前获取了数据
+
+	data, err := clt.TagUserList(tagId, NextOpenId)
+	if err != nil {
+		return
+	}
+
+	iter = &TagUserIterator{
+		clt:                 clt,
+		tagId:               tagId,
+		lastTagUserListData: data,
+		nextPageHasCalled:   false,
+	}
+	return
+}
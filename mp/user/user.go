@@ -27,6 +27,19 @@ const (
 	SexFemale  = 2 // 女性
 )
 
+// 用户关注的渠道来源
+const (
+	SubscribeSceneSearch              = "ADD_SCENE_SEARCH"               // 公众号搜索
+	SubscribeSceneAccountMigration    = "ADD_SCENE_ACCOUNT_MIGRATION"    // 公众号迁移
+	SubscribeSceneProfileCard         = "ADD_SCENE_PROFILE_CARD"         // 名片分享
+	SubscribeSceneQRCode              = "ADD_SCENE_QR_CODE"              // 扫描二维码
+	SubscribeSceneProfileLink         = "ADD_SCENE_PROFILE_LINK"         // 图文页内名称点击
+	SubscribeSceneProfileItem         = "ADD_SCENE_PROFILE_ITEM"         // 图文页右上角菜单
+	SubscribeScenePaid                = "ADD_SCENE_PAID"                 // 支付后关注
+	SubscribeSceneWechatAdvertisement = "ADD_SCENE_WECHAT_ADVERTISEMENT" // 微信广告
+	SubscribeSceneOthers              = "ADD_SCENE_OTHERS"               // 其他
+)
+
 type UserInfo struct {
 	IsSubscriber int    `json:"subscribe"` // 用户是否订阅该公众号标识, 值为0时, 代表此用户没有关注该公众号, 拉取不到其余信息
 	OpenId       string `json:"openid"`    // 用户的标识, 对当前公众号唯一
@@ -49,6 +62,11 @@ type UserInfo struct {
 
 	Remark  string `json:"remark"`  // 公众号运营者对粉丝的备注, 公众号运营者可在微信公众平台用户管理界面对粉丝添加备注
 	GroupId int64  `json:"groupid"` // 用户所在的分组ID
+
+	TagIdList      []int64 `json:"tagid_list"`      // 用户被打上的标签ID列表
+	SubscribeScene string  `json:"subscribe_scene"` // 用户关注的渠道来源, 见 SubscribeSceneXXX
+	QRScene        int64   `json:"qr_scene"`        // 二维码扫码场景(开发者自定义)
+	QRSceneStr     string  `json:"qr_scene_str"`    // 二维码扫码场景描述(开发者自定义)
 }
 
 var ErrNoHeadImage = errors.New("没有头像")
@@ -0,0 +1,232 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// 把消息 marshal 为 XML, 和 encoding/xml 一样根据 xml tag 确定元素名称, 区别是 string 类型的值
+// 用 <![CDATA[...]]> 包裹, 和微信文档的格式保持一致.
+//  支持的 xml tag 格式: "name", "a>b", "name,omitempty", "-";
+//  匿名字段(比如 MessageHeader)的字段会提升到上一层;
+//  实现了 xml.Marshaler 接口的字段交给 encoding/xml 处理.
+func MarshalCDATAXML(msg interface{}) ([]byte, error) {
+	v := reflect.ValueOf(msg)
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil, fmt.Errorf("nil message")
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("unsupported message type: %s", v.Type())
+	}
+
+	rootName := "xml"
+	if sf, ok := v.Type().FieldByName("XMLName"); ok {
+		if name := strings.Split(sf.Tag.Get("xml"), ",")[0]; name != "" {
+			rootName = name
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("<" + rootName + ">")
+	if err := writeCDATAFields(&buf, v); err != nil {
+		return nil, err
+	}
+	buf.WriteString("</" + rootName + ">")
+	return buf.Bytes(), nil
+}
+
+var xmlMarshalerType = reflect.TypeOf((*xml.Marshaler)(nil)).Elem()
+
+func writeCDATAFields(buf *bytes.Buffer, v reflect.Value) error {
+	t := v.Type()
+	for i, n := 0, t.NumField(); i < n; i++ {
+		sf := t.Field(i)
+		if sf.Name == "XMLName" || (sf.PkgPath != "" && !sf.Anonymous) {
+			continue
+		}
+		tag := sf.Tag.Get("xml")
+		if tag == "-" {
+			continue
+		}
+		name, omitempty := tag, false
+		if index := strings.Index(tag, ","); index >= 0 {
+			name = tag[:index]
+			omitempty = strings.Contains(tag[index:], ",omitempty")
+		}
+
+		fv := v.Field(i)
+		if sf.Anonymous && name == "" {
+			for fv.Kind() == reflect.Ptr {
+				if fv.IsNil() {
+					break
+				}
+				fv = fv.Elem()
+			}
+			if fv.Kind() == reflect.Struct {
+				if err := writeCDATAFields(buf, fv); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		if omitempty && isEmptyXMLValue(fv) {
+			continue
+		}
+		if err := writeCDATAElement(buf, strings.Split(name, ">"), fv); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// path 为 "a>b>c" 拆分后的元素名称, 对于 slice 每个元素都用最后一个名称包裹.
+func writeCDATAElement(buf *bytes.Buffer, path []string, v reflect.Value) error {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+
+	parents, name := path[:len(path)-1], path[len(path)-1]
+	for _, parent := range parents {
+		buf.WriteString("<" + parent + ">")
+	}
+	if v.Kind() == reflect.Slice && v.Type().Elem().Kind() != reflect.Uint8 && !v.Type().Implements(xmlMarshalerType) {
+		for i, n := 0, v.Len(); i < n; i++ {
+			if err := writeCDATAValue(buf, name, v.Index(i)); err != nil {
+				return err
+			}
+		}
+	} else {
+		if err := writeCDATAValue(buf, name, v); err != nil {
+			return err
+		}
+	}
+	for i := len(parents) - 1; i >= 0; i-- {
+		buf.WriteString("</" + parents[i] + ">")
+	}
+	return nil
+}
+
+func writeCDATAValue(buf *bytes.Buffer, name string, v reflect.Value) error {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+
+	if v.Type().Implements(xmlMarshalerType) || (v.CanAddr() && v.Addr().Type().Implements(xmlMarshalerType)) {
+		encoder := xml.NewEncoder(buf)
+		if err := encoder.EncodeElement(v.Interface(), xml.StartElement{Name: xml.Name{Local: name}}); err != nil {
+			return err
+		}
+		return encoder.Flush()
+	}
+
+	buf.WriteString("<" + name + ">")
+	switch v.Kind() {
+	case reflect.String:
+		writeCDATA(buf, v.String())
+	case reflect.Struct:
+		if err := writeCDATAFields(buf, v); err != nil {
+			return err
+		}
+	case reflect.Bool:
+		buf.WriteString(strconv.FormatBool(v.Bool()))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		buf.WriteString(strconv.FormatInt(v.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		buf.WriteString(strconv.FormatUint(v.Uint(), 10))
+	case reflect.Float32, reflect.Float64:
+		buf.WriteString(strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits()))
+	case reflect.Slice: // []byte
+		writeCDATA(buf, string(v.Bytes()))
+	default:
+		return fmt.Errorf("unsupported type: %s", v.Type())
+	}
+	buf.WriteString("</" + name + ">")
+	return nil
+}
+
+// 写入 <![CDATA[s]]>, s 里的 "]]>" 拆分到两个 CDATA 段里;
+// XML 不允许的字符(比如 \x01 等控制字符)和不合法的 UTF-8 替换为 U+FFFD, 和 encoding/xml 一样.
+func writeCDATA(buf *bytes.Buffer, s string) {
+	buf.WriteString("<![CDATA[")
+	buf.WriteString(strings.Replace(toXMLChars(s), "]]>", "]]]]><![CDATA[>", -1))
+	buf.WriteString("]]>")
+}
+
+// 把 s 里 XML 不允许的字符和不合法的 UTF-8 替换为 U+FFFD.
+func toXMLChars(s string) string {
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if (r == utf8.RuneError && size == 1) || !isInXMLCharRange(r) {
+			return replaceXMLChars(s, i)
+		}
+		i += size
+	}
+	return s // 绝大部分情况没有需要替换的字符, 不分配内存
+}
+
+// 从 s[start:] 开始替换, s[:start] 已经检查过.
+func replaceXMLChars(s string, start int) string {
+	var buf bytes.Buffer
+	buf.Grow(len(s) + 8)
+	buf.WriteString(s[:start])
+	for i := start; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if (r == utf8.RuneError && size == 1) || !isInXMLCharRange(r) {
+			buf.WriteRune('\uFFFD')
+		} else {
+			buf.WriteString(s[i : i+size])
+		}
+		i += size
+	}
+	return buf.String()
+}
+
+// 和 encoding/xml 的 isInCharacterRange 一样, see https://www.w3.org/TR/xml/#charsets
+func isInXMLCharRange(r rune) bool {
+	return r == 0x09 ||
+		r == 0x0A ||
+		r == 0x0D ||
+		r >= 0x20 && r <= 0xD7FF ||
+		r >= 0xE000 && r <= 0xFFFD ||
+		r >= 0x10000 && r <= 0x10FFFF
+}
+
+func isEmptyXMLValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Ptr:
+		return v.IsNil()
+	}
+	return false
+}
@@ -0,0 +1,113 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"encoding/xml"
+	"testing"
+)
+
+type testCDATAArticle struct {
+	Title string `xml:"Title,omitempty"`
+	URL   string `xml:"Url,omitempty"`
+}
+
+type testCDATANews struct {
+	XMLName struct{} `xml:"xml"`
+	MessageHeader
+
+	ArticleCount int                `xml:"ArticleCount"`
+	Articles     []testCDATAArticle `xml:"Articles>item,omitempty"`
+	Image        struct {
+		MediaId string `xml:"MediaId"`
+	} `xml:"Image"`
+	Ignored string `xml:"-"`
+	Empty   string `xml:"Empty,omitempty"`
+}
+
+func TestMarshalCDATAXML(t *testing.T) {
+	var msg testCDATANews
+	msg.ToUserName = "toUser"
+	msg.FromUserName = "fromUser"
+	msg.CreateTime = 12345678
+	msg.MsgType = "news"
+	msg.ArticleCount = 2
+	msg.Articles = []testCDATAArticle{
+		{Title: "a]]>b<c>&d", URL: "http://example.com/?a=1&b=2"},
+		{Title: "]]>]]>"},
+	}
+	msg.Image.MediaId = "media_id"
+	msg.Ignored = "ignored"
+
+	data, err := MarshalCDATAXML(&msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `<xml>` +
+		`<ToUserName><![CDATA[toUser]]></ToUserName>` +
+		`<FromUserName><![CDATA[fromUser]]></FromUserName>` +
+		`<CreateTime>12345678</CreateTime>` +
+		`<MsgType><![CDATA[news]]></MsgType>` +
+		`<ArticleCount>2</ArticleCount>` +
+		`<Articles>` +
+		`<item><Title><![CDATA[a]]]]><![CDATA[>b<c>&d]]></Title><Url><![CDATA[http://example.com/?a=1&b=2]]></Url></item>` +
+		`<item><Title><![CDATA[]]]]><![CDATA[>]]]]><![CDATA[>]]></Title></item>` +
+		`</Articles>` +
+		`<Image><MediaId><![CDATA[media_id]]></MediaId></Image>` +
+		`</xml>`
+	if string(data) != want {
+		t.Errorf("MarshalCDATAXML:\nhave %s\nwant %s", data, want)
+		return
+	}
+
+	// 用 encoding/xml 解析回来, 内容必须和原来一样
+	var msg2 testCDATANews
+	if err = xml.Unmarshal(data, &msg2); err != nil {
+		t.Error(err)
+		return
+	}
+	msg2.Ignored = msg.Ignored
+	if msg2.MessageHeader != msg.MessageHeader || msg2.ArticleCount != msg.ArticleCount ||
+		len(msg2.Articles) != len(msg.Articles) || msg2.Image != msg.Image {
+		t.Errorf("round trip:\nhave %+v\nwant %+v", msg2, msg)
+		return
+	}
+	for i := range msg.Articles {
+		if msg2.Articles[i] != msg.Articles[i] {
+			t.Errorf("round trip Articles[%d]: have %+v, want %+v", i, msg2.Articles[i], msg.Articles[i])
+		}
+	}
+}
+
+func TestMarshalCDATAXMLInvalidChars(t *testing.T) {
+	var msg struct {
+		XMLName struct{} `xml:"xml"`
+		Content string   `xml:"Content"`
+	}
+	msg.Content = "a\x01b\x0bc\xffd\U0001F600e\uFFFEf]]>g"
+
+	data, err := MarshalCDATAXML(&msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "<xml><Content><![CDATA[a\uFFFDb\uFFFDc\uFFFDd\U0001F600e\uFFFDf]]]]><![CDATA[>g]]></Content></xml>"
+	if string(data) != want {
+		t.Errorf("MarshalCDATAXML:\nhave %q\nwant %q", data, want)
+		return
+	}
+
+	var msg2 struct {
+		Content string `xml:"Content"`
+	}
+	if err = xml.Unmarshal(data, &msg2); err != nil {
+		t.Error(err)
+		return
+	}
+	if want := "a\uFFFDb\uFFFDc\uFFFDd\U0001F600e\uFFFDf]]>g"; msg2.Content != want {
+		t.Errorf("round trip:\nhave %q\nwant %q", msg2.Content, want)
+	}
+}